@@ -0,0 +1,613 @@
+// Package main provides tests for authenticated proxy access.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExecuteSendsBearerToken(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	var capturedRequest *http.Request
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			capturedRequest = req
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	req := plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"module_path": "github.com/user/repo",
+			"proxy_url":   "https://goproxy.example.com",
+			"proxy_token": "s3cr3t",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	}
+
+	resp, err := p.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	if got := capturedRequest.Header.Get("Authorization"); got != "Bearer s3cr3t" {
+		t.Errorf("expected bearer Authorization header, got %q", got)
+	}
+	for key, value := range resp.Outputs {
+		if s, ok := value.(string); ok && strings.Contains(s, "s3cr3t") {
+			t.Errorf("output %s leaks the token: %s", key, s)
+		}
+	}
+}
+
+func TestExecuteWithoutTokenSendsNoAuthorization(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	var capturedRequest *http.Request
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			capturedRequest = req
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	req := plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  map[string]any{"module_path": "github.com/user/repo"},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	}
+
+	if _, err := p.Execute(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := capturedRequest.Header.Get("Authorization"); got != "" {
+		t.Errorf("expected no Authorization header, got %q", got)
+	}
+}
+
+func TestExecuteRedactsTokenFromErrors(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	// A misbehaving proxy echoing the credentials back in its error body.
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mockResponse(http.StatusForbidden, "rejected "+req.Header.Get("Authorization")), nil
+		},
+	}
+
+	buf := captureLogs(t)
+	p := &GoModPlugin{}
+	req := plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"module_path": "github.com/user/repo",
+			"proxy_url":   "https://goproxy.example.com",
+			"proxy_token": "s3cr3t",
+			"log_level":   "debug",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	}
+
+	resp, err := p.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected failure for 403")
+	}
+	if strings.Contains(resp.Error, "s3cr3t") {
+		t.Errorf("expected token to be redacted from error, got: %s", resp.Error)
+	}
+	if !strings.Contains(resp.Error, "[REDACTED]") {
+		t.Errorf("expected redaction marker in error, got: %s", resp.Error)
+	}
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Errorf("expected token to be absent from logs, got: %s", buf.String())
+	}
+}
+
+func TestExecuteRedactsSecretsFromNestedOutputs(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	// A misbehaving proxy echoing the credentials back in its error body.
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mockResponse(http.StatusForbidden, "rejected "+req.Header.Get("Authorization")), nil
+		},
+	}
+
+	root := writeGoWork(t, "use (\n\t./a\n\t./b\n)\n", map[string]string{
+		"a": "github.com/example/mono/a",
+		"b": "github.com/example/mono/b",
+	})
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		secrets []string
+	}{
+		{
+			name:    "proxy token",
+			config:  map[string]any{"proxy_token": "s3cr3t"},
+			secrets: []string{"s3cr3t"},
+		},
+		{
+			name:    "proxy password",
+			config:  map[string]any{"proxy_username": "deploy", "proxy_password": "hunter2"},
+			secrets: []string{"hunter2", base64.StdEncoding.EncodeToString([]byte("deploy:hunter2"))},
+		},
+		{
+			name:    "proxy token across versions",
+			config:  map[string]any{"proxy_token": "s3cr3t", "versions": "v1.0.0,v1.0.1"},
+			secrets: []string{"s3cr3t"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"go_mod_path":    filepath.Join(root, "go.mod"),
+				"from_workspace": true,
+				"proxy_url":      "https://goproxy.example.com",
+				"max_retries":    0,
+			}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success {
+				t.Fatal("expected failure for 403")
+			}
+			if _, ok := resp.Outputs["results"]; !ok {
+				t.Fatalf("expected nested results, got %v", resp.Outputs)
+			}
+
+			outputs := fmt.Sprint(resp.Outputs)
+			for _, secret := range tt.secrets {
+				if strings.Contains(outputs, secret) {
+					t.Errorf("expected %q to be redacted from outputs, got: %s", secret, outputs)
+				}
+			}
+			if !strings.Contains(outputs, "[REDACTED]") {
+				t.Errorf("expected redaction marker in outputs, got: %s", outputs)
+			}
+		})
+	}
+}
+
+func TestProxyTokenFromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]any
+		env      map[string]string
+		expected string
+	}{
+		{
+			name:     "GOPROXY_TOKEN",
+			env:      map[string]string{"GOPROXY_TOKEN": "from-goproxy"},
+			expected: "from-goproxy",
+		},
+		{
+			name:     "GO_PROXY_TOKEN",
+			env:      map[string]string{"GO_PROXY_TOKEN": "from-go-proxy"},
+			expected: "from-go-proxy",
+		},
+		{
+			name:     "GO_PROXY_TOKEN wins over GOPROXY_TOKEN",
+			env:      map[string]string{"GO_PROXY_TOKEN": "from-go-proxy", "GOPROXY_TOKEN": "from-goproxy"},
+			expected: "from-go-proxy",
+		},
+		{
+			name:     "config wins over env",
+			config:   map[string]any{"proxy_token": "from-config"},
+			env:      map[string]string{"GO_PROXY_TOKEN": "from-go-proxy"},
+			expected: "from-config",
+		},
+	}
+
+	p := &GoModPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GO_PROXY_TOKEN", "")
+			t.Setenv("GOPROXY_TOKEN", "")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			config := map[string]any{"module_path": "github.com/user/repo"}
+			for key, value := range tt.config {
+				config[key] = value
+			}
+
+			cfg := p.parseConfig(config)
+			if cfg.ProxyToken != tt.expected {
+				t.Errorf("expected token %q, got %q", tt.expected, cfg.ProxyToken)
+			}
+		})
+	}
+}
+
+func TestExecuteSendsTokenFromEnv(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	t.Setenv("GOPROXY_TOKEN", "")
+	t.Setenv("GO_PROXY_TOKEN", "env-token")
+
+	var capturedRequest *http.Request
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			capturedRequest = req
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	req := plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  map[string]any{"module_path": "github.com/user/repo", "proxy_url": "https://goproxy.example.com"},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	}
+
+	resp, err := p.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := capturedRequest.Header.Get("Authorization"); got != "Bearer env-token" {
+		t.Errorf("expected bearer Authorization header, got %q", got)
+	}
+	for key, value := range resp.Outputs {
+		if s, ok := value.(string); ok && strings.Contains(s, "env-token") {
+			t.Errorf("output %s leaks the token: %s", key, s)
+		}
+	}
+}
+
+func TestExecuteSendsCredentialsOnlyToConfiguredProxy(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name         string
+		config       map[string]any
+		env          map[string]string
+		expectedAuth map[string]string
+	}{
+		{
+			name: "fallback to a public proxy",
+			config: map[string]any{
+				"proxy_url":   "https://goproxy.example.com,https://proxy.golang.org",
+				"proxy_token": "s3cr3t",
+			},
+			expectedAuth: map[string]string{"goproxy.example.com": "Bearer s3cr3t", "proxy.golang.org": ""},
+		},
+		{
+			name:         "env token with the default proxy",
+			env:          map[string]string{"GO_PROXY_TOKEN": "env-token"},
+			expectedAuth: map[string]string{"proxy.golang.org": ""},
+		},
+		{
+			name: "plain HTTP proxy",
+			config: map[string]any{
+				"proxy_url":         "http://goproxy.example.com",
+				"insecure_patterns": "github.com/user",
+				"proxy_username":    "athens",
+				"proxy_password":    "hunter2",
+			},
+			expectedAuth: map[string]string{"goproxy.example.com": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GO_PROXY_TOKEN", "")
+			t.Setenv("GOPROXY_TOKEN", "")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			auth := map[string]string{}
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					auth[req.URL.Host] = req.Header.Get("Authorization")
+					if req.URL.Host == "goproxy.example.com" && req.URL.Scheme == "https" {
+						return mockResponse(http.StatusInternalServerError, "down"), nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			config := map[string]any{"module_path": "github.com/user/repo", "max_retries": 0}
+			for key, value := range tt.config {
+				config[key] = value
+			}
+
+			p := &GoModPlugin{}
+			if _, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fmt.Sprint(auth) != fmt.Sprint(tt.expectedAuth) {
+				t.Errorf("expected Authorization headers %v, got %v", tt.expectedAuth, auth)
+			}
+		})
+	}
+}
+
+func TestAllowPrivateProxy(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantValid bool
+	}{
+		{
+			name: "private proxy rejected by default",
+			config: map[string]any{
+				"module_path": "github.com/user/repo",
+				"proxy_url":   "https://10.0.0.5/proxy",
+			},
+		},
+		{
+			name: "allow flag without token",
+			config: map[string]any{
+				"module_path":         "github.com/user/repo",
+				"proxy_url":           "https://10.0.0.5/proxy",
+				"allow_private_proxy": true,
+			},
+			wantValid: true,
+		},
+		{
+			name: "allow flag with internal domain",
+			config: map[string]any{
+				"module_path":         "github.com/user/repo",
+				"proxy_url":           "https://athens.corp.internal",
+				"allow_private_proxy": true,
+			},
+			wantValid: true,
+		},
+		{
+			name: "allow flag keeps HTTPS requirement",
+			config: map[string]any{
+				"module_path":         "github.com/user/repo",
+				"proxy_url":           "http://10.0.0.5/proxy",
+				"allow_private_proxy": true,
+			},
+		},
+		{
+			name: "token without allow flag",
+			config: map[string]any{
+				"module_path": "github.com/user/repo",
+				"proxy_url":   "https://10.0.0.5/proxy",
+				"proxy_token": "s3cr3t",
+			},
+		},
+		{
+			name: "token with allow flag",
+			config: map[string]any{
+				"module_path":         "github.com/user/repo",
+				"proxy_url":           "https://10.0.0.5/proxy",
+				"proxy_token":         "s3cr3t",
+				"allow_private_proxy": true,
+			},
+			wantValid: true,
+		},
+		{
+			name: "localhost stays blocked",
+			config: map[string]any{
+				"module_path":         "github.com/user/repo",
+				"proxy_url":           "https://localhost/proxy",
+				"proxy_token":         "s3cr3t",
+				"allow_private_proxy": true,
+			},
+		},
+	}
+
+	p := &GoModPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Errorf("expected valid=%v, got %v (errors: %v)", tt.wantValid, resp.Valid, resp.Errors)
+			}
+		})
+	}
+}
+
+func TestExecutePrivateProxy(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name        string
+		allow       bool
+		wantSuccess bool
+	}{
+		{name: "rejected by default"},
+		{name: "accepted when allowed", allow: true, wantSuccess: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requested = req.URL.String()
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":         "github.com/user/repo",
+					"proxy_url":           "https://10.0.0.5/proxy",
+					"allow_private_proxy": tt.allow,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Fatalf("expected success=%v, got %v (error: %s)", tt.wantSuccess, resp.Success, resp.Error)
+			}
+			if tt.wantSuccess && requested != "https://10.0.0.5/proxy/github.com/user/repo/@v/v1.0.0.info" {
+				t.Errorf("unexpected request URL: %s", requested)
+			}
+			if !tt.wantSuccess && requested != "" {
+				t.Errorf("expected no request, got %s", requested)
+			}
+		})
+	}
+}
+
+func TestExecuteSendsBasicAuth(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name     string
+		config   map[string]any
+		wantAuth bool
+	}{
+		{
+			name:     "username and password",
+			config:   map[string]any{"proxy_username": "athens", "proxy_password": "hunter2"},
+			wantAuth: true,
+		},
+		{
+			name:   "username only",
+			config: map[string]any{"proxy_username": "athens"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedRequest *http.Request
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					capturedRequest = req
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			config := map[string]any{"module_path": "github.com/user/repo", "proxy_url": "https://goproxy.example.com"}
+			for key, value := range tt.config {
+				config[key] = value
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			}
+
+			if _, err := p.Execute(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			user, pass, ok := capturedRequest.BasicAuth()
+			if ok != tt.wantAuth {
+				t.Fatalf("expected basic auth=%v, got %v", tt.wantAuth, ok)
+			}
+			if ok && (user != "athens" || pass != "hunter2") {
+				t.Errorf("unexpected credentials %q/%q", user, pass)
+			}
+		})
+	}
+}
+
+func TestExecuteRedactsBasicAuthFromErrors(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mockResponse(http.StatusUnauthorized, "bad credentials hunter2 "+req.Header.Get("Authorization")), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	req := plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"module_path":    "github.com/user/repo",
+			"proxy_username": "athens",
+			"proxy_password": "hunter2",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	}
+
+	resp, err := p.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected failure for 401")
+	}
+	if strings.Contains(resp.Error, "hunter2") || strings.Contains(resp.Error, "YXRoZW5zOmh1bnRlcjI=") {
+		t.Errorf("expected credentials to be redacted, got: %s", resp.Error)
+	}
+}
+
+func TestValidateProxyPasswordRequiresUsername(t *testing.T) {
+	p := &GoModPlugin{}
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"module_path":    "github.com/user/repo",
+		"proxy_password": "hunter2",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid {
+		t.Fatal("expected validation to fail")
+	}
+
+	found := false
+	for _, e := range resp.Errors {
+		if e.Field == "proxy_password" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected error on field proxy_password, got: %v", resp.Errors)
+	}
+}
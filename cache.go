@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// requestCache remembers successful notifications for the lifetime of one
+// Execute call, keyed by request URL, so a module@version listed twice is
+// only sent to the proxy once. Failures are never stored: a later request
+// for the same URL tries again.
+type requestCache struct {
+	mu       sync.Mutex
+	results  map[string]indexResult
+	inflight map[string]chan struct{}
+}
+
+// newRequestCache returns an empty cache.
+func newRequestCache() *requestCache {
+	return &requestCache{
+		results:  make(map[string]indexResult),
+		inflight: make(map[string]chan struct{}),
+	}
+}
+
+// acquire returns the cached result for key, if any. Otherwise the caller
+// becomes the only one requesting key and must call release when done;
+// concurrent callers for the same key wait for it rather than duplicating
+// the request. A nil cache never hits and needs no release.
+func (c *requestCache) acquire(ctx context.Context, key string) (*indexResult, bool, error) {
+	if c == nil {
+		return nil, false, nil
+	}
+	for {
+		c.mu.Lock()
+		if result, ok := c.results[key]; ok {
+			c.mu.Unlock()
+			return &result, true, nil
+		}
+		wait, busy := c.inflight[key]
+		if !busy {
+			c.inflight[key] = make(chan struct{})
+			c.mu.Unlock()
+			return nil, false, nil
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+}
+
+// release stores result for key if the request succeeded and wakes any
+// callers waiting on it. It is a no-op on a nil cache.
+func (c *requestCache) release(key string, result *indexResult, err error) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil && result != nil {
+		c.results[key] = *result
+	}
+	close(c.inflight[key])
+	delete(c.inflight, key)
+}
@@ -0,0 +1,145 @@
+// Package main provides tests for the per-Execute request cache.
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExecuteRequestCache(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		disableCache    bool
+		maxConcurrency  int
+		statuses        []int
+		expectedCalls   int
+		expectedSuccess bool
+	}{
+		{name: "cached", maxConcurrency: 4, statuses: []int{http.StatusOK}, expectedCalls: 1, expectedSuccess: true},
+		{name: "cache disabled", disableCache: true, maxConcurrency: 4, statuses: []int{http.StatusOK}, expectedCalls: 2, expectedSuccess: true},
+		{name: "failures are not cached", maxConcurrency: 1, statuses: []int{http.StatusGone, http.StatusOK}, expectedCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			calls := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					status := tt.statuses[min(calls, len(tt.statuses)-1)]
+					calls++
+					return mockResponse(status, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":     "github.com/user/repo,github.com/user/repo",
+					"disable_cache":   tt.disableCache,
+					"max_concurrency": tt.maxConcurrency,
+					"max_retries":     0,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Errorf("expected success=%v, got %v (error: %s)", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d proxy calls, got %d", tt.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestExecuteRequestCacheIsPerExecute(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	calls := 0
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	for range 2 {
+		resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+			Hook:    plugin.HookPostPublish,
+			Config:  map[string]any{"module_path": "github.com/user/repo"},
+			Context: plugin.ReleaseContext{Version: "1.0.0"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("expected success, got error: %s", resp.Error)
+		}
+		if resp.Outputs["cached"] != nil {
+			t.Errorf("expected no cached output, got %v", resp.Outputs["cached"])
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected each Execute call to notify the proxy, got %d calls", calls)
+	}
+}
+
+func TestRequestCache(t *testing.T) {
+	ctx := context.Background()
+	const key = "https://proxy.golang.org/github.com/user/repo/@v/v1.0.0.info"
+
+	var nilCache *requestCache
+	if _, hit, err := nilCache.acquire(ctx, key); hit || err != nil {
+		t.Errorf("expected a nil cache to miss, got hit=%v err=%v", hit, err)
+	}
+	nilCache.release(key, &indexResult{}, nil)
+
+	c := newRequestCache()
+	if _, hit, _ := c.acquire(ctx, key); hit {
+		t.Fatal("expected an empty cache to miss")
+	}
+	c.release(key, &indexResult{Result: proxy.Result{StatusCode: http.StatusInternalServerError}}, errors.New("server error"))
+
+	if _, hit, _ := c.acquire(ctx, key); hit {
+		t.Fatal("expected a failure not to be cached")
+	}
+	c.release(key, &indexResult{Result: proxy.Result{StatusCode: http.StatusOK}}, nil)
+
+	result, hit, err := c.acquire(ctx, key)
+	if err != nil || !hit {
+		t.Fatalf("expected a hit, got hit=%v err=%v", hit, err)
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("expected cached status 200, got %d", result.StatusCode)
+	}
+
+	// A caller waiting on an in-flight request gives up with its context.
+	other := "https://proxy.golang.org/github.com/user/other/@v/v1.0.0.info"
+	if _, hit, _ := c.acquire(ctx, other); hit {
+		t.Fatal("expected a miss")
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := c.acquire(cancelled, other); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
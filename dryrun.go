@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+)
+
+// dryRunCheck runs the read-only @v/list request behind dry_run_verify and
+// describes what it found. It never fails the release: reachable reports
+// whether the proxy answered, and the finding is meant for the dry-run message.
+func (p *GoModPlugin) dryRunCheck(ctx context.Context, cfg *Config, version string) (finding string, reachable bool) {
+	versions, err := p.listVersions(ctx, cfg)
+	if err != nil {
+		return fmt.Sprintf("proxy check failed: %v", err), false
+	}
+	if slices.Contains(versions, version) {
+		return fmt.Sprintf("proxy is reachable but already lists %s", version), true
+	}
+	if len(versions) == 0 {
+		return "proxy is reachable and does not list the module yet", true
+	}
+	return fmt.Sprintf("proxy is reachable and lists %d other versions", len(versions)), true
+}
+
+// dryRunConnectivity sends a single HEAD request, without retries, to the
+// root of each configured proxy in turn and reports on the first one that
+// answers. It touches no module endpoint, so it cannot trigger indexing.
+// Like the pre-publish check, only transport errors and server errors count
+// as unreachable. tlsVersion names the negotiated TLS version, if known.
+func (p *GoModPlugin) dryRunConnectivity(ctx context.Context, cfg *Config) (finding string, reachable bool, tlsVersion string) {
+	proxies := parseProxyList(cfg.ProxyURL, cfg.proxyPolicy())
+	if len(proxies.URLs) == 0 {
+		return "no usable proxy URL configured", false, ""
+	}
+
+	client, err := cfg.newHTTPClient()
+	if err != nil {
+		return fmt.Sprintf("proxy check failed: %v", err), false, ""
+	}
+
+	var lastErr error
+	for _, proxyURL := range proxies.URLs {
+		if lastErr = cfg.checkRequestURL(ctx, proxyURL); lastErr != nil {
+			continue
+		}
+		resp, _, err := proxyDo(ctx, client, cfg, http.MethodHead, proxyURL)
+		switch {
+		case err != nil:
+			lastErr = err
+		case resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("proxy returned status %d", resp.StatusCode)
+		default:
+			if resp.TLS != nil {
+				tlsVersion = tls.VersionName(resp.TLS.Version)
+			}
+			return fmt.Sprintf("%s is reachable", proxy.RedactURL(proxyURL)), true, tlsVersion
+		}
+	}
+	return fmt.Sprintf("proxy is unreachable: %v", cfg.redact(lastErr.Error())), false, ""
+}
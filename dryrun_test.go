@@ -0,0 +1,238 @@
+// Package main provides tests for dry-run verification.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExecuteDryRunVerify(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name              string
+		dryRunVerify      bool
+		listStatus        int
+		listBody          string
+		transportErr      bool
+		expectedRequests  int
+		expectedReachable any
+		messageContains   string
+	}{
+		{
+			name:              "reachable proxy",
+			dryRunVerify:      true,
+			listStatus:        http.StatusOK,
+			listBody:          "v1.0.0\nv1.0.1\n",
+			expectedRequests:  1,
+			expectedReachable: true,
+			messageContains:   "proxy is reachable and lists 2 other versions",
+		},
+		{
+			name:              "version already listed",
+			dryRunVerify:      true,
+			listStatus:        http.StatusOK,
+			listBody:          "v1.0.0\nv1.1.0\n",
+			expectedRequests:  1,
+			expectedReachable: true,
+			messageContains:   "already lists v1.1.0",
+		},
+		{
+			name:              "unknown module",
+			dryRunVerify:      true,
+			listStatus:        http.StatusNotFound,
+			expectedRequests:  1,
+			expectedReachable: true,
+			messageContains:   "does not list the module yet",
+		},
+		{
+			name:              "unreachable proxy",
+			dryRunVerify:      true,
+			transportErr:      true,
+			expectedRequests:  1,
+			expectedReachable: false,
+			messageContains:   "proxy check failed",
+		},
+		{
+			name:              "server error",
+			dryRunVerify:      true,
+			listStatus:        http.StatusServiceUnavailable,
+			expectedRequests:  1,
+			expectedReachable: false,
+			messageContains:   "proxy check failed",
+		},
+		{
+			name:            "plain dry run makes no requests",
+			messageContains: "Would notify Go module proxy for github.com/user/repo@v1.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req.URL.Path)
+					if tt.transportErr {
+						return nil, errors.New("connection refused")
+					}
+					return mockResponse(tt.listStatus, tt.listBody), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":    "github.com/user/repo",
+					"dry_run_verify": tt.dryRunVerify,
+				},
+				Context: plugin.ReleaseContext{Version: "1.1.0"},
+				DryRun:  true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Findings are reported, never fatal.
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if len(requests) != tt.expectedRequests {
+				t.Fatalf("expected %d requests, got %v", tt.expectedRequests, requests)
+			}
+			for _, path := range requests {
+				if path != "/github.com/user/repo/@v/list" {
+					t.Errorf("expected only @v/list requests, got %s", path)
+				}
+			}
+			if resp.Outputs["proxy_reachable"] != tt.expectedReachable {
+				t.Errorf("expected proxy_reachable %v, got %v", tt.expectedReachable, resp.Outputs["proxy_reachable"])
+			}
+			if !strings.Contains(resp.Message, tt.messageContains) {
+				t.Errorf("expected message containing %q, got: %s", tt.messageContains, resp.Message)
+			}
+		})
+	}
+}
+
+func TestExecuteDryRunConnectivity(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name               string
+		connectivity       bool
+		status             int
+		tlsState           *tls.ConnectionState
+		transportErr       bool
+		expectedRequests   int
+		expectedReachable  any
+		expectedTLSVersion any
+		messageContains    string
+	}{
+		{
+			name:               "reachable over TLS 1.3",
+			connectivity:       true,
+			status:             http.StatusOK,
+			tlsState:           &tls.ConnectionState{Version: tls.VersionTLS13},
+			expectedRequests:   1,
+			expectedReachable:  true,
+			expectedTLSVersion: "TLS 1.3",
+			messageContains:    "connectivity check: https://proxy.golang.org is reachable",
+		},
+		{
+			name:               "root not found still reachable",
+			connectivity:       true,
+			status:             http.StatusNotFound,
+			expectedRequests:   1,
+			expectedReachable:  true,
+			expectedTLSVersion: "",
+			messageContains:    "is reachable",
+		},
+		{
+			name:               "server error",
+			connectivity:       true,
+			status:             http.StatusBadGateway,
+			expectedRequests:   1,
+			expectedReachable:  false,
+			expectedTLSVersion: "",
+			messageContains:    "proxy is unreachable: proxy returned status 502",
+		},
+		{
+			name:               "transport error is not retried",
+			connectivity:       true,
+			transportErr:       true,
+			expectedRequests:   1,
+			expectedReachable:  false,
+			expectedTLSVersion: "",
+			messageContains:    "proxy is unreachable",
+		},
+		{
+			name:            "disabled by default",
+			messageContains: "Would notify Go module proxy for github.com/user/repo@v1.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []*http.Request
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req)
+					if tt.transportErr {
+						return nil, errors.New("connection refused")
+					}
+					resp := mockResponse(tt.status, "")
+					resp.TLS = tt.tlsState
+					return resp, nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":          "github.com/user/repo",
+					"dry_run_connectivity": tt.connectivity,
+				},
+				Context: plugin.ReleaseContext{Version: "1.1.0"},
+				DryRun:  true,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Findings are reported, never fatal.
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if len(requests) != tt.expectedRequests {
+				t.Fatalf("expected %d requests, got %d", tt.expectedRequests, len(requests))
+			}
+			for _, req := range requests {
+				if req.Method != http.MethodHead || req.URL.String() != "https://proxy.golang.org" {
+					t.Errorf("expected HEAD https://proxy.golang.org, got %s %s", req.Method, req.URL)
+				}
+			}
+			if resp.Outputs["reachable"] != tt.expectedReachable {
+				t.Errorf("expected reachable %v, got %v", tt.expectedReachable, resp.Outputs["reachable"])
+			}
+			if resp.Outputs["tls_version"] != tt.expectedTLSVersion {
+				t.Errorf("expected tls_version %v, got %v", tt.expectedTLSVersion, resp.Outputs["tls_version"])
+			}
+			if !strings.Contains(resp.Message, tt.messageContains) {
+				t.Errorf("expected message containing %q, got: %s", tt.messageContains, resp.Message)
+			}
+		})
+	}
+}
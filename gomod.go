@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Default go.mod location used to detect the module path.
+const defaultGoModPath = "./go.mod"
+
+// workspaceEnvVars lists environment variables CI systems use to expose the
+// checked-out repository. ReleaseContext carries no working directory, so a
+// relative go_mod_path is also resolved against these when present.
+var workspaceEnvVars = []string{"GITHUB_WORKSPACE", "CI_PROJECT_DIR", "BITBUCKET_CLONE_DIR"}
+
+// detectModulePath reads the module path from goModPath, retrying relative
+// paths against the release's workspace directory when the file is not found
+// in the plugin's working directory.
+func detectModulePath(goModPath string, env map[string]string) (string, error) {
+	modulePath, err := readModulePath(goModPath)
+	if err == nil || filepath.IsAbs(goModPath) {
+		return modulePath, err
+	}
+
+	for _, key := range workspaceEnvVars {
+		dir := env[key]
+		if dir == "" {
+			continue
+		}
+		if detected, wsErr := readModulePath(filepath.Join(dir, goModPath)); wsErr == nil {
+			return detected, nil
+		}
+	}
+	return "", err
+}
+
+// detectModulePaths returns the module paths to publish when none are
+// configured. With fromGoWork set, the modules used by the go.work next to
+// goModPath are tried first; otherwise, or if that fails, the module declared
+// in goModPath is used.
+func detectModulePaths(goModPath string, fromGoWork bool, env map[string]string) ([]string, error) {
+	if fromGoWork {
+		goWorkPath := filepath.Join(filepath.Dir(goModPath), "go.work")
+		if modulePaths, err := detectGoWorkModules(goWorkPath, env); err == nil {
+			return modulePaths, nil
+		}
+	}
+
+	modulePath, err := detectModulePath(goModPath, env)
+	if err != nil {
+		return nil, err
+	}
+	return []string{modulePath}, nil
+}
+
+// detectGoWorkModules is like readGoWorkModules but, as detectModulePath
+// does, retries relative paths against the release's workspace directory.
+func detectGoWorkModules(goWorkPath string, env map[string]string) ([]string, error) {
+	modulePaths, err := readGoWorkModules(goWorkPath)
+	if err == nil || filepath.IsAbs(goWorkPath) {
+		return modulePaths, err
+	}
+
+	for _, key := range workspaceEnvVars {
+		dir := env[key]
+		if dir == "" {
+			continue
+		}
+		if detected, wsErr := readGoWorkModules(filepath.Join(dir, goWorkPath)); wsErr == nil {
+			return detected, nil
+		}
+	}
+	return nil, err
+}
+
+// readGoWorkModules reads the module path of every directory named by a use
+// directive in a go.work file, in order and without duplicates.
+func readGoWorkModules(goWorkPath string) ([]string, error) {
+	file, err := os.Open(goWorkPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	dirs, err := parseGoWorkUses(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", goWorkPath, err)
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("%s: no use directives found", goWorkPath)
+	}
+
+	var modulePaths []string
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(goWorkPath), dir)
+		}
+		modulePath, err := readModulePath(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(modulePaths, modulePath) {
+			modulePaths = append(modulePaths, modulePath)
+		}
+	}
+	return modulePaths, nil
+}
+
+// parseGoWorkUses extracts the directories named by use directives in go.work
+// content, in both the single-line and the parenthesized block form.
+func parseGoWorkUses(r io.Reader) ([]string, error) {
+	var dirs []string
+	inBlock := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			// Block entries are the directory alone.
+		case fields[0] != "use":
+			continue
+		case len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+			continue
+		default:
+			fields = fields[1:]
+		}
+
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("malformed use directive %q", strings.TrimSpace(line))
+		}
+		dir := fields[0]
+		if strings.HasPrefix(dir, `"`) {
+			unquoted, err := strconv.Unquote(dir)
+			if err != nil {
+				return nil, fmt.Errorf("malformed use directive %q", strings.TrimSpace(line))
+			}
+			dir = unquoted
+		}
+		dirs = append(dirs, dir)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inBlock {
+		return nil, fmt.Errorf("unterminated use block")
+	}
+	return dirs, nil
+}
+
+// readModulePath reads the module path from the first module directive of a
+// go.mod file. Trailing comments are ignored and quoted paths are unquoted.
+func readModulePath(goModPath string) (string, error) {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	return parseModulePath(file)
+}
+
+// parseModulePath extracts the module path from the first module directive
+// in go.mod content.
+func parseModulePath(r io.Reader) (string, error) {
+	var err error
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "module" {
+			continue
+		}
+		if len(fields) != 2 {
+			return "", fmt.Errorf("malformed module directive %q", line)
+		}
+
+		modulePath := fields[1]
+		if strings.HasPrefix(modulePath, `"`) {
+			if modulePath, err = strconv.Unquote(modulePath); err != nil {
+				return "", fmt.Errorf("malformed module directive %q", line)
+			}
+		}
+		return modulePath, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("no module directive found")
+}
@@ -0,0 +1,417 @@
+// Package main provides tests for go.mod module path detection.
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// writeGoMod writes a go.mod with the given content into a temp directory
+// and returns its path.
+func writeGoMod(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "go.mod")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+	return path
+}
+
+func TestReadModulePath(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    string
+		errContains string
+	}{
+		{
+			name:     "normal go.mod",
+			content:  "module github.com/example/module\n\ngo 1.22\n",
+			expected: "github.com/example/module",
+		},
+		{
+			name:     "trailing comment",
+			content:  "// Leading comment\nmodule github.com/example/module // the module\n\ngo 1.22\n",
+			expected: "github.com/example/module",
+		},
+		{
+			name:     "quoted path",
+			content:  "module \"github.com/example/module\"\n",
+			expected: "github.com/example/module",
+		},
+		{
+			name:     "only the first module directive is used",
+			content:  "module github.com/example/first\nmodule github.com/example/second\n",
+			expected: "github.com/example/first",
+		},
+		{
+			name:        "no module directive",
+			content:     "go 1.22\n",
+			errContains: "no module directive",
+		},
+		{
+			name:        "malformed module directive",
+			content:     "module github.com/a github.com/b\n",
+			errContains: "malformed module directive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readModulePath(writeGoMod(t, tt.content))
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("expected error containing '%s', got: %v", tt.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestReadModulePathMissingFile(t *testing.T) {
+	_, err := readModulePath(filepath.Join(t.TempDir(), "go.mod"))
+	if !os.IsNotExist(err) {
+		t.Errorf("expected not-exist error, got: %v", err)
+	}
+}
+
+func TestParseConfigDetectsModulePath(t *testing.T) {
+	_ = os.Unsetenv("GO_MODULE_PATH")
+
+	p := &GoModPlugin{}
+	goModPath := writeGoMod(t, "module github.com/example/detected // comment\n")
+
+	cfg := p.parseConfig(map[string]any{"go_mod_path": goModPath})
+	if cfg.ModulePath != "github.com/example/detected" {
+		t.Errorf("expected detected module path, got '%s'", cfg.ModulePath)
+	}
+
+	// An explicit module_path always wins over go.mod.
+	cfg = p.parseConfig(map[string]any{
+		"module_path": "github.com/example/explicit",
+		"go_mod_path": goModPath,
+	})
+	if cfg.ModulePath != "github.com/example/explicit" {
+		t.Errorf("expected explicit module path, got '%s'", cfg.ModulePath)
+	}
+}
+
+func TestValidateGoModDetection(t *testing.T) {
+	_ = os.Unsetenv("GO_MODULE_PATH")
+
+	p := &GoModPlugin{}
+	ctx := context.Background()
+
+	resp, err := p.Validate(ctx, map[string]any{
+		"go_mod_path": writeGoMod(t, "module github.com/example/detected\n"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Valid {
+		t.Errorf("expected valid config, got errors: %v", resp.Errors)
+	}
+
+	missing := filepath.Join(t.TempDir(), "go.mod")
+	resp, err = p.Validate(ctx, map[string]any{"go_mod_path": missing})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid {
+		t.Fatal("expected invalid config when go.mod is missing")
+	}
+	if resp.Errors[0].Field != "module_path" || !strings.Contains(resp.Errors[0].Message, missing) {
+		t.Errorf("expected module_path error mentioning %s, got: %v", missing, resp.Errors)
+	}
+}
+
+func TestDetectModulePathFromWorkspace(t *testing.T) {
+	chdirTemp(t)
+
+	workspace := filepath.Dir(writeGoMod(t, "module github.com/example/workspace\n"))
+
+	tests := []struct {
+		name        string
+		goModPath   string
+		env         map[string]string
+		expected    string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:      "github workspace",
+			goModPath: defaultGoModPath,
+			env:       map[string]string{"GITHUB_WORKSPACE": workspace},
+			expected:  "github.com/example/workspace",
+		},
+		{
+			name:      "gitlab project dir",
+			goModPath: "go.mod",
+			env:       map[string]string{"CI_PROJECT_DIR": workspace},
+			expected:  "github.com/example/workspace",
+		},
+		{
+			name:      "no workspace",
+			goModPath: defaultGoModPath,
+			wantErr:   true,
+		},
+		{
+			name:      "absolute path is not re-resolved",
+			goModPath: filepath.Join(t.TempDir(), "go.mod"),
+			env:       map[string]string{"GITHUB_WORKSPACE": workspace},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectModulePath(tt.goModPath, tt.env)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got module path %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExecuteDetectsModulePathFromWorkspace(t *testing.T) {
+	chdirTemp(t)
+
+	workspace := filepath.Dir(writeGoMod(t, "module github.com/example/workspace\n"))
+
+	p := &GoModPlugin{}
+	req := plugin.ExecuteRequest{
+		Hook:   plugin.HookPostPublish,
+		Config: map[string]any{},
+		Context: plugin.ReleaseContext{
+			Version:     "1.0.0",
+			Environment: map[string]string{"GITHUB_WORKSPACE": workspace},
+		},
+		DryRun: true,
+	}
+
+	resp, err := p.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if resp.Outputs["module_path"] != "github.com/example/workspace" {
+		t.Errorf("expected detected module path, got %v", resp.Outputs["module_path"])
+	}
+}
+
+// writeGoWork lays out a go.work with the given content in a temp directory,
+// plus a go.mod for each entry of modules keyed by its directory, and
+// returns the directory.
+func writeGoWork(t *testing.T, content string, modules map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.work"), []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write go.work: %v", err)
+	}
+	for dir, modulePath := range modules {
+		dir = filepath.Join(root, dir)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+modulePath+"\n"), 0o600); err != nil {
+			t.Fatalf("failed to write go.mod: %v", err)
+		}
+	}
+	return root
+}
+
+func TestReadGoWorkModules(t *testing.T) {
+	modules := map[string]string{
+		"core":     "github.com/example/mono/core",
+		"cli":      "github.com/example/mono/cli",
+		"internal": "github.com/example/mono/tools",
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		expected    []string
+		errContains string
+	}{
+		{
+			name:     "use block",
+			content:  "go 1.22\n\nuse (\n\t./core\n\t./cli // the CLI\n)\n",
+			expected: []string{"github.com/example/mono/core", "github.com/example/mono/cli"},
+		},
+		{
+			name:     "single-line and quoted directives",
+			content:  "go 1.22\nuse ./core\nuse \"./internal\"\n",
+			expected: []string{"github.com/example/mono/core", "github.com/example/mono/tools"},
+		},
+		{
+			name:     "duplicates are dropped",
+			content:  "use ./core\nuse ./core/\n",
+			expected: []string{"github.com/example/mono/core"},
+		},
+		{
+			name:        "no use directives",
+			content:     "go 1.22\n",
+			errContains: "no use directives",
+		},
+		{
+			name:        "missing go.mod",
+			content:     "use ./missing\n",
+			errContains: "go.mod",
+		},
+		{
+			name:        "unterminated block",
+			content:     "use (\n\t./core\n",
+			errContains: "unterminated use block",
+		},
+		{
+			name:        "malformed directive",
+			content:     "use ./core ./cli\n",
+			errContains: "malformed use directive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeGoWork(t, tt.content, modules)
+			got, err := readGoWorkModules(filepath.Join(root, "go.work"))
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("expected error containing %q, got %v", tt.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseConfigFromWorkspace(t *testing.T) {
+	_ = os.Unsetenv("GO_MODULE_PATH")
+
+	root := writeGoWork(t, "use (\n\t.\n\t./cli\n)\n", map[string]string{
+		".":   "github.com/example/mono",
+		"cli": "github.com/example/mono/cli",
+	})
+	goModPath := filepath.Join(root, "go.mod")
+
+	p := &GoModPlugin{}
+
+	cfg := p.parseConfig(map[string]any{"go_mod_path": goModPath, "from_workspace": true})
+	expected := []string{"github.com/example/mono", "github.com/example/mono/cli"}
+	if strings.Join(cfg.ModulePaths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected module paths %v, got %v", expected, cfg.ModulePaths)
+	}
+	if cfg.ModulePath != expected[0] {
+		t.Errorf("expected module path %s, got %s", expected[0], cfg.ModulePath)
+	}
+
+	// Without from_workspace only go.mod is used.
+	cfg = p.parseConfig(map[string]any{"go_mod_path": goModPath})
+	if len(cfg.ModulePaths) != 1 || cfg.ModulePath != "github.com/example/mono" {
+		t.Errorf("expected only the go.mod module, got %v", cfg.ModulePaths)
+	}
+
+	// Without a go.work, go.mod is still used.
+	cfg = p.parseConfig(map[string]any{
+		"go_mod_path":    writeGoMod(t, "module github.com/example/single\n"),
+		"from_workspace": true,
+	})
+	if len(cfg.ModulePaths) != 1 || cfg.ModulePath != "github.com/example/single" {
+		t.Errorf("expected the go.mod fallback, got %v", cfg.ModulePaths)
+	}
+}
+
+func TestValidateFromWorkspace(t *testing.T) {
+	_ = os.Unsetenv("GO_MODULE_PATH")
+
+	p := &GoModPlugin{}
+	ctx := context.Background()
+
+	root := writeGoWork(t, "use (\n\t./good\n\t./bad\n)\n", map[string]string{
+		"good": "github.com/example/mono/good",
+		"bad":  "github.com/example/../bad",
+	})
+
+	resp, err := p.Validate(ctx, map[string]any{
+		"go_mod_path":    filepath.Join(root, "go.mod"),
+		"from_workspace": true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid {
+		t.Fatal("expected invalid config for a discovered invalid module path")
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Field != "module_path" || !strings.Contains(resp.Errors[0].Message, "'..'") {
+		t.Errorf("expected a single module_path error for the discovered path, got %v", resp.Errors)
+	}
+}
+
+func TestExecuteFromWorkspace(t *testing.T) {
+	chdirTemp(t)
+	_ = os.Unsetenv("GO_MODULE_PATH")
+
+	root := writeGoWork(t, "use ./a\nuse ./b\n", map[string]string{
+		"a": "github.com/example/mono/a",
+		"b": "github.com/example/mono/b",
+	})
+
+	p := &GoModPlugin{}
+	req := plugin.ExecuteRequest{
+		Hook:   plugin.HookPostPublish,
+		Config: map[string]any{"from_workspace": true},
+		Context: plugin.ReleaseContext{
+			Version:     "1.0.0",
+			Environment: map[string]string{"GITHUB_WORKSPACE": root},
+		},
+		DryRun: true,
+	}
+
+	resp, err := p.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	statuses, ok := resp.Outputs["statuses"].(map[string]string)
+	if !ok || len(statuses) != 2 {
+		t.Fatalf("expected statuses for both workspace modules, got %v", resp.Outputs["statuses"])
+	}
+	for _, modulePath := range []string{"github.com/example/mono/a", "github.com/example/mono/b"} {
+		if _, ok := statuses[modulePath]; !ok {
+			t.Errorf("expected status for %s, got %v", modulePath, statuses)
+		}
+	}
+}
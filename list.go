@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+)
+
+// parseVersionList parses the newline-delimited body of an @v/list response.
+func parseVersionList(body string) []string {
+	var versions []string
+	for _, line := range strings.Split(body, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			versions = append(versions, line)
+		}
+	}
+	return versions
+}
+
+// listVersions fetches the versions the proxy knows for the module from
+// {proxy}/{module}/@v/list, trying each configured proxy in order. A module
+// the proxy has never seen (404/410) has no versions.
+func (p *GoModPlugin) listVersions(ctx context.Context, cfg *Config) ([]string, error) {
+	proxies := parseProxyList(cfg.ProxyURL, cfg.proxyPolicy())
+	if len(proxies.URLs) == 0 {
+		return nil, fmt.Errorf("no usable proxy URL configured")
+	}
+
+	client, err := cfg.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for _, proxyURL := range proxies.URLs {
+		requestURL := proxy.ModuleURL(proxyURL, cfg.ModulePath, "@v/list")
+		if lastErr = cfg.checkRequestURL(ctx, requestURL); lastErr != nil {
+			continue
+		}
+
+		resp, body, err := proxyGet(ctx, client, cfg, requestURL)
+		switch {
+		case err != nil:
+			lastErr = err
+		case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+			return nil, nil
+		case resp.StatusCode != http.StatusOK:
+			lastErr = fmt.Errorf("proxy returned error status %d: %s", resp.StatusCode, string(body))
+		default:
+			return parseVersionList(string(body)), nil
+		}
+	}
+	return nil, fmt.Errorf("failed to list versions: %w", lastErr)
+}
+
+// checkVersionAbsent fails if the proxy already lists the version.
+func (p *GoModPlugin) checkVersionAbsent(ctx context.Context, cfg *Config, version string) error {
+	versions, err := p.listVersions(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(versions, version) {
+		return fmt.Errorf("version %s of %s already exists on the proxy", version, cfg.ModulePath)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+// Package main provides tests for the @v/list endpoint.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestParseVersionList(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected []string
+	}{
+		{name: "multi-line", body: "v1.0.0\nv1.1.0\nv1.2.0-rc.1\n", expected: []string{"v1.0.0", "v1.1.0", "v1.2.0-rc.1"}},
+		{name: "CRLF and blank lines", body: "v1.0.0\r\n\r\nv1.1.0", expected: []string{"v1.0.0", "v1.1.0"}},
+		{name: "empty", body: "", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseVersionList(tt.body)
+			if fmt.Sprint(got) != fmt.Sprint(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExecuteFailIfExists(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		failIfExists    bool
+		listStatus      int
+		listBody        string
+		expectedSuccess bool
+		expectedPaths   []string
+		errContains     string
+	}{
+		{
+			name:          "conflict",
+			failIfExists:  true,
+			listStatus:    http.StatusOK,
+			listBody:      "v1.0.0\nv1.1.0\n",
+			expectedPaths: []string{"/github.com/user/repo/@v/list"},
+			errContains:   "version v1.1.0 of github.com/user/repo already exists on the proxy",
+		},
+		{
+			name:            "no conflict",
+			failIfExists:    true,
+			listStatus:      http.StatusOK,
+			listBody:        "v1.0.0\nv1.1.0-rc.1\n",
+			expectedSuccess: true,
+			expectedPaths:   []string{"/github.com/user/repo/@v/list", "/github.com/user/repo/@v/v1.1.0.info"},
+		},
+		{
+			name:            "unknown module",
+			failIfExists:    true,
+			listStatus:      http.StatusNotFound,
+			expectedSuccess: true,
+			expectedPaths:   []string{"/github.com/user/repo/@v/list", "/github.com/user/repo/@v/v1.1.0.info"},
+		},
+		{
+			name:          "list fails",
+			failIfExists:  true,
+			listStatus:    http.StatusInternalServerError,
+			expectedPaths: []string{"/github.com/user/repo/@v/list"},
+			errContains:   "failed to list versions",
+		},
+		{
+			name:            "disabled",
+			listStatus:      http.StatusOK,
+			listBody:        "v1.1.0\n",
+			expectedSuccess: true,
+			expectedPaths:   []string{"/github.com/user/repo/@v/v1.1.0.info"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					if strings.HasSuffix(req.URL.Path, "/@v/list") {
+						return mockResponse(tt.listStatus, tt.listBody), nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":    "github.com/user/repo",
+					"fail_if_exists": tt.failIfExists,
+				},
+				Context: plugin.ReleaseContext{Version: "1.1.0"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(tt.expectedPaths) {
+				t.Errorf("expected requests %v, got %v", tt.expectedPaths, paths)
+			}
+		})
+	}
+}
+
+func TestExecuteCheckExisting(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		listStatus      int
+		listBody        string
+		expectedIndexed bool
+		expectedPaths   []string
+	}{
+		{
+			name:            "already indexed",
+			listStatus:      http.StatusOK,
+			listBody:        "v1.0.0\nv1.1.0\n",
+			expectedIndexed: true,
+			expectedPaths:   []string{"/github.com/user/repo/@v/list"},
+		},
+		{
+			name:          "not yet indexed",
+			listStatus:    http.StatusOK,
+			listBody:      "v1.0.0\n",
+			expectedPaths: []string{"/github.com/user/repo/@v/list", "/github.com/user/repo/@v/v1.1.0.info"},
+		},
+		{
+			name:          "unknown module",
+			listStatus:    http.StatusNotFound,
+			expectedPaths: []string{"/github.com/user/repo/@v/list", "/github.com/user/repo/@v/v1.1.0.info"},
+		},
+		{
+			name:          "list fails still notifies",
+			listStatus:    http.StatusInternalServerError,
+			expectedPaths: []string{"/github.com/user/repo/@v/list", "/github.com/user/repo/@v/v1.1.0.info"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					if strings.HasSuffix(req.URL.Path, "/@v/list") {
+						return mockResponse(tt.listStatus, tt.listBody), nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":    "github.com/user/repo",
+					"check_existing": true,
+				},
+				Context: plugin.ReleaseContext{Version: "1.1.0"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if indexed := resp.Outputs["already_indexed"] == true; indexed != tt.expectedIndexed {
+				t.Errorf("expected already_indexed=%v, got %v", tt.expectedIndexed, resp.Outputs["already_indexed"])
+			}
+			if tt.expectedIndexed && !strings.Contains(resp.Message, "already indexed") {
+				t.Errorf("expected message to mention already indexed, got: %s", resp.Message)
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(tt.expectedPaths) {
+				t.Errorf("expected requests %v, got %v", tt.expectedPaths, paths)
+			}
+		})
+	}
+}
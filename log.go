@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+)
+
+// Supported log levels, from most to least verbose.
+const (
+	logLevelDebug = "debug"
+	logLevelInfo  = "info"
+	logLevelWarn  = "warn"
+	logLevelError = "error"
+)
+
+// Default log level.
+const defaultLogLevel = logLevelError
+
+// logLevels ranks log levels by severity.
+var logLevels = map[string]int{
+	logLevelDebug: 0,
+	logLevelInfo:  1,
+	logLevelWarn:  2,
+	logLevelError: 3,
+}
+
+// logger is the logger used for structured plugin logs.
+// Can be overridden in tests.
+var logger proxy.Logger = newJSONLogger(os.Stderr)
+
+// jsonLogger writes each entry as a single JSON line.
+type jsonLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// newJSONLogger creates a logger writing JSON lines to w.
+func newJSONLogger(w io.Writer) *jsonLogger {
+	return &jsonLogger{w: w}
+}
+
+// Log writes the entry with its level, message and timestamp.
+func (l *jsonLogger) Log(level, msg string, fields map[string]any) {
+	entry := make(map[string]any, len(fields)+3)
+	for k, v := range fields {
+		// Never emit credentials, even if a caller passes them along.
+		if strings.EqualFold(k, "authorization") {
+			v = "[REDACTED]"
+		}
+		entry[k] = v
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = level
+	entry["msg"] = msg
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.w.Write(append(data, '\n'))
+}
+
+// logEnabled reports whether entries at level pass the configured threshold.
+func logEnabled(configured, level string) bool {
+	threshold, ok := logLevels[configured]
+	if !ok {
+		threshold = logLevels[defaultLogLevel]
+	}
+	return logLevels[level] >= threshold
+}
+
+// logf emits an entry if the configured level allows it.
+func (cfg *Config) logf(level, msg string, fields map[string]any) {
+	if logEnabled(cfg.LogLevel, level) {
+		logger.Log(level, msg, fields)
+	}
+}
+
+// proxyLogger passes the proxy package's log entries through the configured
+// log level, redacting secrets from string fields.
+type proxyLogger struct {
+	cfg *Config
+}
+
+// Log emits the entry if the configured level allows it.
+func (l proxyLogger) Log(level, msg string, fields map[string]any) {
+	for key, value := range fields {
+		if s, ok := value.(string); ok {
+			fields[key] = l.cfg.redact(s)
+		}
+	}
+	l.cfg.logf(level, msg, fields)
+}
+
+// stripUserinfo removes any user credentials embedded in a URL entirely,
+// username included, so it can be exposed as an output.
+func stripUserinfo(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,126 @@
+// Package main provides tests for proxy request metrics.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// fakeRecorder records every observation it receives.
+type fakeRecorder struct {
+	mu       sync.Mutex
+	statuses []int
+	proxies  []string
+	retries  map[string]int
+}
+
+func (r *fakeRecorder) ObserveRequest(proxyURL string, status int, _ time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.proxies = append(r.proxies, proxyURL)
+	r.statuses = append(r.statuses, status)
+}
+
+func (r *fakeRecorder) IncRetry(proxyURL string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.retries == nil {
+		r.retries = make(map[string]int)
+	}
+	r.retries[proxyURL]++
+}
+
+// useMetrics installs r as the metrics recorder for the duration of the test,
+// restoring the no-op recorder afterwards.
+func useMetrics(t *testing.T, r proxy.MetricsRecorder) {
+	t.Helper()
+	proxy.SetMetricsRecorder(r)
+	t.Cleanup(func() { proxy.SetMetricsRecorder(nil) })
+}
+
+func TestMetricsRecorderObservesEachAttempt(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name             string
+		responses        []int
+		transportErr     bool
+		expectedStatuses []int
+		expectedRetries  int
+	}{
+		{
+			name:             "success on first attempt",
+			responses:        []int{http.StatusOK},
+			expectedStatuses: []int{http.StatusOK},
+		},
+		{
+			name:             "retries until success",
+			responses:        []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedStatuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedRetries:  2,
+		},
+		{
+			name:             "non-retryable failure",
+			responses:        []int{http.StatusGone},
+			expectedStatuses: []int{http.StatusGone},
+		},
+		{
+			name:             "transport error reports status 0",
+			transportErr:     true,
+			expectedStatuses: []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeRecorder{}
+			useMetrics(t, recorder)
+
+			attempt := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					defer func() { attempt++ }()
+					if tt.transportErr {
+						return nil, errors.New("connection refused")
+					}
+					return mockResponse(tt.responses[attempt], `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			_, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":      "github.com/user/repo",
+					"retry_backoff_ms": 1,
+				},
+				Context: plugin.ReleaseContext{Version: "v1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fmt.Sprint(recorder.statuses) != fmt.Sprint(tt.expectedStatuses) {
+				t.Errorf("expected statuses %v, got %v", tt.expectedStatuses, recorder.statuses)
+			}
+			for _, proxyURL := range recorder.proxies {
+				if proxyURL != defaultProxyURL {
+					t.Errorf("expected proxy %s, got %s", defaultProxyURL, proxyURL)
+				}
+			}
+			if got := recorder.retries[defaultProxyURL]; got != tt.expectedRetries {
+				t.Errorf("expected %d retries, got %d", tt.expectedRetries, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// hostResolver resolves hostnames to IP addresses.
+type hostResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// dnsResolver is the resolver used to check proxy hosts.
+// Can be overridden in tests.
+var dnsResolver hostResolver = &net.Resolver{}
+
+// blockedNetworks lists address ranges a proxy host must never resolve to.
+// Documentation ranges never route to a real proxy, so an address in one is
+// a misconfiguration or an attempt to probe how the host is filtered.
+var blockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",       // "This" network
+	"10.0.0.0/8",      // Private
+	"100.64.0.0/10",   // Carrier-grade NAT
+	"127.0.0.0/8",     // Loopback
+	"169.254.0.0/16",  // Link-local
+	"172.16.0.0/12",   // Private
+	"192.168.0.0/16",  // Private
+	"192.0.2.0/24",    // Documentation (TEST-NET-1)
+	"198.51.100.0/24", // Documentation (TEST-NET-2)
+	"203.0.113.0/24",  // Documentation (TEST-NET-3)
+	"::/128",          // Unspecified
+	"::1/128",         // Loopback
+	"fc00::/7",        // Unique local
+	"fe80::/10",       // Link-local
+	"2001:db8::/32",   // Documentation
+)
+
+// mustParseCIDRs parses a list of CIDR blocks, panicking on invalid input.
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// isBlockedIP reports whether ip falls in a private, loopback, link-local,
+// CGNAT or documentation range. IPv4-mapped IPv6 addresses are checked as IPv4.
+func isBlockedIP(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkResolvedHost resolves host and fails if any of its addresses is in a
+// blocked range, so a public-looking name cannot point at internal services.
+// It reports a bad host before any request is made; the connection itself is
+// guarded by checkedDialContext, which checks the address actually dialed.
+func checkResolvedHost(ctx context.Context, host string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err := resolveAllowed(ctx, host)
+	return err
+}
+
+// resolveAllowed resolves host and returns its addresses, failing if any of
+// them is in a blocked range. IP literals are checked without a lookup.
+func resolveAllowed(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		if isBlockedIP(ip) {
+			return nil, fmt.Errorf("proxy host %s is a disallowed address", host)
+		}
+		return []net.IP{ip}, nil
+	}
+
+	addrs, err := dnsResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve proxy host %s: %w", host, err)
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("proxy host %s did not resolve to any address", host)
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		if isBlockedIP(addr.IP) {
+			return nil, fmt.Errorf("proxy host %s resolves to disallowed address %s", host, addr.IP)
+		}
+		ips = append(ips, addr.IP)
+	}
+	return ips, nil
+}
+
+// checkedDialContext returns a dial function that resolves the host once,
+// rejects it if any address is blocked and then dials the checked addresses
+// directly. Connecting to the address that was checked closes the window in
+// which a rebinding DNS server could swap in an internal address between a
+// separate check and the dial. Hosts for which checked returns false are
+// dialed as usual.
+func checkedDialContext(dialer *net.Dialer, checked func(host string) bool) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if !checked(host) {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		ips, err := resolveAllowed(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var lastErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	}
+}
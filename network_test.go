@@ -0,0 +1,314 @@
+// Package main provides tests for proxy host resolution checks.
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// fakeResolver resolves hosts from a fixed table, defaulting to a public address.
+type fakeResolver struct {
+	addrs map[string][]string
+	err   error
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, host)
+	r.mu.Unlock()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	ips, ok := r.addrs[host]
+	if !ok {
+		ips = []string{"93.184.216.34"}
+	}
+
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+// TestMain keeps the tests hermetic by resolving every host to a public
+// address unless a test installs its own resolver, and keeps failure logs
+// out of the test output.
+func TestMain(m *testing.M) {
+	dnsResolver = &fakeResolver{}
+	logger = newJSONLogger(io.Discard)
+	// Keep the developer's Go environment from leaking into defaults.
+	os.Unsetenv("GOPROXY")
+	os.Unsetenv("GOPRIVATE")
+	os.Unsetenv("GONOSUMDB")
+	os.Exit(m.Run())
+}
+
+// useResolver installs r as the DNS resolver for the duration of the test.
+func useResolver(t *testing.T, r hostResolver) {
+	t.Helper()
+	original := dnsResolver
+	dnsResolver = r
+	t.Cleanup(func() { dnsResolver = original })
+}
+
+func TestIsBlockedIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		blocked bool
+	}{
+		{ip: "10.0.0.5", blocked: true},
+		{ip: "172.16.0.1", blocked: true},
+		{ip: "192.168.1.1", blocked: true},
+		{ip: "127.0.0.1", blocked: true},
+		{ip: "169.254.169.254", blocked: true},
+		{ip: "100.64.0.1", blocked: true},
+		{ip: "0.0.0.0", blocked: true},
+		{ip: "::1", blocked: true},
+		{ip: "fd00::1", blocked: true},
+		{ip: "fe80::1", blocked: true},
+		{ip: "::ffff:10.0.0.5", blocked: true},
+		{ip: "93.184.216.34"},
+		{ip: "172.32.0.1"},
+		{ip: "2606:4700::1111"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := isBlockedIP(net.ParseIP(tt.ip)); got != tt.blocked {
+				t.Errorf("expected blocked=%v for %s, got %v", tt.blocked, tt.ip, got)
+			}
+		})
+	}
+}
+
+func TestCheckResolvedHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		resolver    *fakeResolver
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:     "public address",
+			resolver: &fakeResolver{},
+		},
+		{
+			name:        "resolves to private address",
+			resolver:    &fakeResolver{addrs: map[string][]string{"proxy.example.com": {"10.0.0.5"}}},
+			wantErr:     true,
+			errContains: "disallowed address 10.0.0.5",
+		},
+		{
+			name:        "one of several addresses is private",
+			resolver:    &fakeResolver{addrs: map[string][]string{"proxy.example.com": {"93.184.216.34", "fd00::1"}}},
+			wantErr:     true,
+			errContains: "disallowed address fd00::1",
+		},
+		{
+			name:        "lookup failure",
+			resolver:    &fakeResolver{err: errors.New("no such host")},
+			wantErr:     true,
+			errContains: "failed to resolve proxy host",
+		},
+		{
+			name:        "no addresses",
+			resolver:    &fakeResolver{addrs: map[string][]string{"proxy.example.com": {}}},
+			wantErr:     true,
+			errContains: "did not resolve",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useResolver(t, tt.resolver)
+
+			err := checkResolvedHost(context.Background(), "proxy.example.com", time.Second)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("expected error containing '%s', got: %v", tt.errContains, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestExecuteDNSCheck(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		wantSuccess bool
+		wantLookup  bool
+	}{
+		{
+			name:       "rebinding host rejected",
+			config:     map[string]any{},
+			wantLookup: true,
+		},
+		{
+			name:        "check disabled",
+			config:      map[string]any{"skip_dns_check": true},
+			wantSuccess: true,
+		},
+		{
+			name:       "resolve_and_check enabled",
+			config:     map[string]any{"resolve_and_check": true},
+			wantLookup: true,
+		},
+		{
+			name:        "resolve_and_check disabled",
+			config:      map[string]any{"resolve_and_check": false},
+			wantSuccess: true,
+		},
+		{
+			name:        "allowlisted host is not resolved",
+			config:      map[string]any{"allowed_hosts": "proxy.example.com"},
+			wantSuccess: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &fakeResolver{addrs: map[string][]string{"proxy.example.com": {"127.0.0.1"}}}
+			useResolver(t, resolver)
+
+			requests := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests++
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			config := map[string]any{
+				"module_path": "github.com/user/repo",
+				"proxy_url":   "https://proxy.example.com",
+			}
+			for key, value := range tt.config {
+				config[key] = value
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Fatalf("expected success=%v, got %v (error: %s)", tt.wantSuccess, resp.Success, resp.Error)
+			}
+			if !tt.wantSuccess && requests != 0 {
+				t.Errorf("expected no request to a rejected host, got %d", requests)
+			}
+			if gotLookup := len(resolver.calls) > 0; gotLookup != tt.wantLookup {
+				t.Errorf("expected lookup=%v, got calls %v", tt.wantLookup, resolver.calls)
+			}
+		})
+	}
+}
+
+func TestCheckedDialContext(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		env         map[string]string
+		host        string
+		errContains string
+	}{
+		{
+			name:        "rebinding host rejected at dial time",
+			host:        "proxy.example.com",
+			errContains: "disallowed address 127.0.0.1",
+		},
+		{
+			name:        "IP literal rejected",
+			host:        "127.0.0.1",
+			errContains: "disallowed address",
+		},
+		{
+			name:   "allowlisted host dialed",
+			config: map[string]any{"allowed_hosts": "127.0.0.1"},
+			host:   "127.0.0.1",
+		},
+		{
+			name: "egress proxy dialed",
+			env:  map[string]string{"HTTPS_PROXY": "http://127.0.0.1:" + port},
+			host: "127.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+				t.Setenv(key, "")
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			// A host resolving to loopback stands in for a DNS server that
+			// answers the pre-flight check and the dial differently.
+			useResolver(t, &fakeResolver{addrs: map[string][]string{"proxy.example.com": {"127.0.0.1"}}})
+
+			transport := transportFor(t, tt.config)
+			if transport.DialContext == nil {
+				t.Fatal("expected a checked dial function")
+			}
+
+			conn, err := transport.DialContext(context.Background(), "tcp", net.JoinHostPort(tt.host, port))
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("expected error containing '%s', got: %v", tt.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			conn.Close()
+		})
+	}
+
+	if transportFor(t, map[string]any{"skip_dns_check": true}).DialContext != nil {
+		t.Error("expected the default dialer with skip_dns_check")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// onError records which module, version and proxy a failed release was
+// about to notify, so downstream tooling can correlate the failure. It never
+// contacts the network and always succeeds.
+//
+// No state survives between hooks, so whether a notification was attempted
+// is inferred from the configuration and release context: the post-publish
+// checks that run before contacting the proxy are repeated, and the first
+// one that fails is reported as the last known error.
+func (p *GoModPlugin) onError(cfg *Config, releaseCtx plugin.ReleaseContext) *plugin.ExecuteResponse {
+	version := releaseCtx.Version
+	if version == "" {
+		version = releaseCtx.TagName
+	}
+	version = proxy.NormalizeVersion(version)
+
+	// Report the proxies post-publish would have tried, parsed exactly as
+	// it parses them; "off" and "direct" are not proxies.
+	proxies := parseProxyList(cfg.ProxyURL, cfg.proxyPolicy()).URLs
+	for i, proxyURL := range proxies {
+		proxies[i] = proxy.RedactURL(proxyURL)
+	}
+	proxyURL := cfg.redact(strings.Join(proxies, ","))
+	proxyDesc := "proxy " + proxyURL
+	if proxyURL == "" {
+		proxyDesc = "no proxy"
+	}
+
+	outputs := map[string]any{
+		"module_path":  cfg.ModulePath,
+		"module_paths": cfg.ModulePaths,
+		"version":      version,
+		"proxy_url":    proxyURL,
+	}
+
+	skipReason, lastErr := notificationBlocker(cfg, releaseCtx)
+	outputs["notification_attempted"] = skipReason == "" && lastErr == ""
+	if lastErr != "" {
+		outputs["last_error"] = cfg.redact(lastErr)
+	}
+
+	message := fmt.Sprintf("Recorded failed Go module release %s@%s (%s)", cfg.ModulePath, version, proxyDesc)
+	switch {
+	case lastErr != "":
+		message += "; proxy notification would have failed: " + cfg.redact(lastErr)
+	case skipReason != "":
+		message += "; proxy notification was skipped: " + skipReason
+	}
+
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: message,
+		Outputs: outputs,
+	}
+}
+
+// notificationBlocker reports why post-publish would not have notified the
+// proxy for cfg.ModulePath: a skip reason for configurations that never
+// notify, or the error that would have stopped the release first. Both are
+// empty when the notification would have been attempted.
+func notificationBlocker(cfg *Config, releaseCtx plugin.ReleaseContext) (skipReason, lastErr string) {
+	if err := cfg.checkModulePath(cfg.ModulePath); err != nil {
+		return "", fmt.Sprintf("invalid module path: %v", err)
+	}
+	if cfg.isPrivate() {
+		return "private module", ""
+	}
+
+	proxies := parseProxyList(cfg.ProxyURL, cfg.proxyPolicy())
+	if len(proxies.URLs) == 0 {
+		if len(proxies.Invalid) > 0 {
+			return "", fmt.Sprintf("invalid proxy URL: %s", strings.Join(proxies.Invalid, "; "))
+		}
+		return "no proxy configured", ""
+	}
+
+	if cfg.AutoVersion && releaseCtx.Version == "" && releaseCtx.TagName == "" {
+		return "", ""
+	}
+	if _, err := releaseVersion(cfg.ModulePath, releaseCtx); err != nil {
+		return "", err.Error()
+	}
+	return "", ""
+}
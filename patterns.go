@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// matchPrefixPatterns reports whether target matches any of the
+// comma-separated glob patterns in globs, and returns the pattern that
+// matched. As with GOPRIVATE in the go command, each pattern is matched with
+// path.Match against the leading path elements of target, so
+// "github.com/mycorp" matches "github.com/mycorp/repo" and
+// "*.corp.example.com" matches "git.corp.example.com/team/repo".
+func matchPrefixPatterns(globs, target string) (string, bool) {
+	for _, glob := range strings.Split(globs, ",") {
+		glob = strings.TrimSuffix(strings.TrimSpace(glob), "/")
+		if glob == "" {
+			continue
+		}
+
+		// Trim target to as many path elements as the pattern has.
+		n := strings.Count(glob, "/")
+		prefix := target
+		for i := 0; i < len(target); i++ {
+			if target[i] == '/' {
+				if n == 0 {
+					prefix = target[:i]
+					break
+				}
+				n--
+			}
+		}
+		if n > 0 {
+			continue
+		}
+
+		if matched, _ := path.Match(glob, prefix); matched {
+			return glob, true
+		}
+	}
+	return "", false
+}
+
+// checkPatterns reports the first malformed glob in a comma-separated list.
+func checkPatterns(globs string) error {
+	for _, glob := range strings.Split(globs, ",") {
+		glob = strings.TrimSpace(glob)
+		if glob == "" {
+			continue
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", glob, err)
+		}
+	}
+	return nil
+}
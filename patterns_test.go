@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestMatchPrefixPatterns(t *testing.T) {
+	tests := []struct {
+		name            string
+		globs           string
+		target          string
+		expectedMatch   bool
+		expectedPattern string
+	}{
+		{
+			name:            "wildcard element",
+			globs:           "github.com/mycorp/*",
+			target:          "github.com/mycorp/repo",
+			expectedMatch:   true,
+			expectedPattern: "github.com/mycorp/*",
+		},
+		{
+			name:            "prefix matches nested path",
+			globs:           "github.com/mycorp",
+			target:          "github.com/mycorp/repo/v2",
+			expectedMatch:   true,
+			expectedPattern: "github.com/mycorp",
+		},
+		{
+			name:            "wildcard host",
+			globs:           "*.corp.example.com",
+			target:          "git.corp.example.com/team/repo",
+			expectedMatch:   true,
+			expectedPattern: "*.corp.example.com",
+		},
+		{
+			name:            "second pattern matches",
+			globs:           "gitlab.com/other, github.com/mycorp/",
+			target:          "github.com/mycorp/repo",
+			expectedMatch:   true,
+			expectedPattern: "github.com/mycorp",
+		},
+		{
+			name:   "element boundary respected",
+			globs:  "github.com/my",
+			target: "github.com/mycorp/repo",
+		},
+		{
+			name:   "pattern longer than target",
+			globs:  "github.com/mycorp/repo/sub",
+			target: "github.com/mycorp/repo",
+		},
+		{
+			name:   "different owner",
+			globs:  "github.com/mycorp/*",
+			target: "github.com/other/repo",
+		},
+		{
+			name:   "empty patterns",
+			globs:  "",
+			target: "github.com/mycorp/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, matched := matchPrefixPatterns(tt.globs, tt.target)
+			if matched != tt.expectedMatch {
+				t.Errorf("expected match=%v, got %v", tt.expectedMatch, matched)
+			}
+			if pattern != tt.expectedPattern {
+				t.Errorf("expected pattern %q, got %q", tt.expectedPattern, pattern)
+			}
+		})
+	}
+}
+
+func TestExecuteGOPRIVATE(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name          string
+		goprivate     string
+		config        map[string]any
+		expectSkipped bool
+	}{
+		{
+			name:          "matching module is skipped",
+			goprivate:     "github.com/mycorp/*",
+			config:        map[string]any{"module_path": "github.com/mycorp/repo"},
+			expectSkipped: true,
+		},
+		{
+			name:      "non-matching module is notified",
+			goprivate: "github.com/mycorp/*",
+			config:    map[string]any{"module_path": "github.com/user/repo"},
+		},
+		{
+			name:      "explicit private false overrides GOPRIVATE",
+			goprivate: "github.com/mycorp/*",
+			config:    map[string]any{"module_path": "github.com/mycorp/repo", "private": false},
+		},
+		{
+			name:          "explicit private true without GOPRIVATE",
+			config:        map[string]any{"module_path": "github.com/user/repo", "private": true},
+			expectSkipped: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GOPRIVATE", tt.goprivate)
+
+			requests := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests++
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  tt.config,
+				Context: plugin.ReleaseContext{Version: "v1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+
+			if tt.expectSkipped {
+				if requests != 0 {
+					t.Errorf("expected no requests, got %d", requests)
+				}
+				if resp.Outputs["skip_reason"] != "private" {
+					t.Errorf("expected skip_reason private, got %v", resp.Outputs["skip_reason"])
+				}
+			} else if requests != 1 {
+				t.Errorf("expected 1 request, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestExecutePrivatePatterns(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		modulePath      string
+		patterns        any
+		private         any
+		expectedPattern string
+		expectSkipped   bool
+	}{
+		{
+			name:            "owner wildcard",
+			modulePath:      "github.com/myorg/repo",
+			patterns:        "github.com/myorg/*,*.corp.example.com",
+			expectedPattern: "github.com/myorg/*",
+			expectSkipped:   true,
+		},
+		{
+			name:            "host wildcard",
+			modulePath:      "git.corp.example.com/team/repo",
+			patterns:        "github.com/myorg/*,*.corp.example.com",
+			expectedPattern: "*.corp.example.com",
+			expectSkipped:   true,
+		},
+		{
+			name:            "array of patterns",
+			modulePath:      "gitlab.com/group/repo/v2",
+			patterns:        []any{"github.com/myorg", "gitlab.com/group"},
+			expectedPattern: "gitlab.com/group",
+			expectSkipped:   true,
+		},
+		{
+			name:       "no match",
+			modulePath: "github.com/other/repo",
+			patterns:   "github.com/myorg/*,*.corp.example.com",
+		},
+		{
+			name:       "partial element does not match",
+			modulePath: "github.com/myorganization/repo",
+			patterns:   "github.com/myorg",
+		},
+		{
+			name:       "private false overrides a match",
+			modulePath: "github.com/myorg/repo",
+			patterns:   "github.com/myorg/*",
+			private:    false,
+		},
+		{
+			name:          "private true without a match",
+			modulePath:    "github.com/other/repo",
+			patterns:      "github.com/myorg/*",
+			private:       true,
+			expectSkipped: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests++
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			config := map[string]any{
+				"module_path":      tt.modulePath,
+				"private_patterns": tt.patterns,
+			}
+			if tt.private != nil {
+				config["private"] = tt.private
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "v2.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+
+			if !tt.expectSkipped {
+				if requests != 1 {
+					t.Errorf("expected 1 request, got %d", requests)
+				}
+				return
+			}
+			if requests != 0 {
+				t.Errorf("expected no requests, got %d", requests)
+			}
+			if resp.Outputs["skipped"] != true {
+				t.Errorf("expected skipped output, got %v", resp.Outputs["skipped"])
+			}
+			if pattern, _ := resp.Outputs["private_pattern"].(string); pattern != tt.expectedPattern {
+				t.Errorf("expected private_pattern %q, got %q", tt.expectedPattern, pattern)
+			}
+		})
+	}
+}
+
+func TestPrivatePatternsFallBackToGOPRIVATE(t *testing.T) {
+	t.Setenv("GOPRIVATE", "github.com/fromenv/*")
+
+	p := &GoModPlugin{}
+	if cfg := p.parseConfig(map[string]any{}); cfg.PrivatePatterns != "github.com/fromenv/*" {
+		t.Errorf("expected GOPRIVATE fallback, got %q", cfg.PrivatePatterns)
+	}
+	if cfg := p.parseConfig(map[string]any{"private_patterns": "github.com/fromconfig"}); cfg.PrivatePatterns != "github.com/fromconfig" {
+		t.Errorf("expected config to win over GOPRIVATE, got %q", cfg.PrivatePatterns)
+	}
+}
+
+func TestValidatePrivatePatterns(t *testing.T) {
+	p := &GoModPlugin{}
+
+	resp, err := p.Validate(context.Background(), map[string]any{
+		"module_path":      "github.com/user/repo",
+		"private_patterns": "github.com/myorg/*,github.com/[bad",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Valid {
+		t.Fatal("expected a malformed pattern to be invalid")
+	}
+	if resp.Errors[0].Field != "private_patterns" {
+		t.Errorf("expected error on field private_patterns, got %s", resp.Errors[0].Field)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Default pkg.go.dev URL.
+const defaultPkgsiteURL = "https://pkg.go.dev"
+
+// pkgsiteURL builds the pkg.go.dev page URL for a module version, e.g.
+// https://pkg.go.dev/github.com/user/repo@v1.0.0.
+func pkgsiteURL(modulePath, version string) string {
+	return defaultPkgsiteURL + "/" + modulePath + "@" + strings.ReplaceAll(version, "+", "%2B")
+}
+
+// warmPkgsite requests the version's pkg.go.dev page, which makes pkgsite
+// fetch and render its documentation instead of waiting for the proxy's
+// index feed. Proxy credentials are never sent to pkg.go.dev.
+func (p *GoModPlugin) warmPkgsite(ctx context.Context, cfg *Config, version string) error {
+	requestURL := pkgsiteURL(cfg.ModulePath, version)
+	if err := cfg.checkRequestURL(ctx, requestURL); err != nil {
+		return err
+	}
+
+	anonymous := cfg.anonymous()
+	client, err := anonymous.newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	resp, _, err := proxyGet(ctx, client, anonymous, requestURL)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("pkg.go.dev returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Package main provides tests for pkg.go.dev warming.
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestPkgsiteURL(t *testing.T) {
+	tests := []struct {
+		modulePath string
+		version    string
+		expected   string
+	}{
+		{modulePath: "github.com/user/repo", version: "v1.2.3", expected: "https://pkg.go.dev/github.com/user/repo@v1.2.3"},
+		{modulePath: "github.com/user/repo", version: "v3.0.0+incompatible", expected: "https://pkg.go.dev/github.com/user/repo@v3.0.0%2Bincompatible"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := pkgsiteURL(tt.modulePath, tt.version); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExecuteWarmPkgsite(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name           string
+		warmPkgsite    bool
+		pkgsiteStatus  int
+		expectedWarms  int
+		expectedWarmed any
+		expectWarning  bool
+	}{
+		{
+			name:           "page rendered",
+			warmPkgsite:    true,
+			pkgsiteStatus:  http.StatusOK,
+			expectedWarms:  1,
+			expectedWarmed: true,
+		},
+		{
+			name:           "not found is a warning",
+			warmPkgsite:    true,
+			pkgsiteStatus:  http.StatusNotFound,
+			expectedWarms:  1,
+			expectedWarmed: false,
+			expectWarning:  true,
+		},
+		{
+			name:          "disabled",
+			pkgsiteStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warms []*http.Request
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Host == "pkg.go.dev" {
+						warms = append(warms, req)
+						return mockResponse(tt.pkgsiteStatus, "<html></html>"), nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":  "github.com/user/repo",
+					"warm_pkgsite": tt.warmPkgsite,
+					"proxy_token":  "secret-token",
+				},
+				Context: plugin.ReleaseContext{Version: "1.2.3"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+
+			if len(warms) != tt.expectedWarms {
+				t.Fatalf("expected %d pkg.go.dev requests, got %d", tt.expectedWarms, len(warms))
+			}
+			for _, warm := range warms {
+				if warm.URL.String() != "https://pkg.go.dev/github.com/user/repo@v1.2.3" {
+					t.Errorf("unexpected pkg.go.dev URL %s", warm.URL)
+				}
+				if warm.Header.Get("Authorization") != "" {
+					t.Error("expected no proxy credentials on the pkg.go.dev request")
+				}
+			}
+			if resp.Outputs["pkgsite_warmed"] != tt.expectedWarmed {
+				t.Errorf("expected pkgsite_warmed %v, got %v", tt.expectedWarmed, resp.Outputs["pkgsite_warmed"])
+			}
+			if got := strings.Contains(resp.Message, "could not warm pkg.go.dev"); got != tt.expectWarning {
+				t.Errorf("expected warning=%v, got message: %s", tt.expectWarning, resp.Message)
+			}
+		})
+	}
+}
+
+func TestExecuteWarmPkgsiteFailedNotification(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Host == "pkg.go.dev" {
+				t.Errorf("unexpected pkg.go.dev request after a failed notification")
+			}
+			return mockResponse(http.StatusGone, "gone"), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"module_path":  "github.com/user/repo",
+			"warm_pkgsite": true,
+		},
+		Context: plugin.ReleaseContext{Version: "1.2.3"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected failure")
+	}
+}
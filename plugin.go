@@ -4,77 +4,270 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
 	"fmt"
-	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/relicta-tech/plugin-gomod/proxy"
 	"github.com/relicta-tech/relicta-plugin-sdk/helpers"
 	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+	"golang.org/x/mod/module"
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/idna"
 )
 
 // Default Go module proxy URL.
-const defaultProxyURL = "https://proxy.golang.org"
+const defaultProxyURL = proxy.DefaultURL
+
+// publicProxyHosts are well-known public proxies for which TLS verification
+// may never be disabled.
+var publicProxyHosts = []string{
+	"proxy.golang.org",
+	"goproxy.io",
+	"goproxy.cn",
+	"proxy.golang.com.cn",
+}
+
+// insecureSkipVerifyWarning prefixes messages while insecure_skip_verify is on.
+const insecureSkipVerifyWarning = "WARNING: TLS certificate verification is disabled (insecure_skip_verify)"
+
+// Supported tls_min_version values.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Default number of redirects followed.
+const defaultMaxRedirects = 3
+
+// Default minimum TLS version.
+const defaultTLSMinVersion = "1.3"
+
+// Default User-Agent sent with proxy requests.
+const defaultUserAgent = proxy.DefaultUserAgent
 
 // Default timeout in seconds.
 const defaultTimeout = 30
 
+// Default connection pool limits of the HTTP transport.
+const (
+	defaultMaxIdleConns        = 10
+	defaultMaxIdleConnsPerHost = 5
+)
+
+// Default number of modules notified in parallel.
+const defaultMaxConcurrency = 4
+
+// Default retry budget and delay between attempts.
+const (
+	defaultMaxRetries     = 3
+	defaultRetryBackoffMs = 1000
+)
+
+// Default time to wait for the proxy to confirm a version, in seconds.
+const defaultVerifyTimeout = 60
+
+// Special GOPROXY list entries.
+const (
+	proxyDirect = "direct"
+	proxyOff    = "off"
+)
+
 // httpClient is the HTTP client used for requests.
 // Can be overridden in tests.
 var httpClient HTTPClient = nil
 
+// retrySleep waits between proxy notification attempts.
+// Can be overridden in tests.
+var retrySleep = proxy.SleepContext
+
 // HTTPClient interface for HTTP operations (allows mocking in tests).
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
 // getHTTPClient returns the HTTP client to use for requests.
-func getHTTPClient(timeout time.Duration) HTTPClient {
+func getHTTPClient(timeout time.Duration, opts ...clientOption) HTTPClient {
 	if httpClient != nil {
 		return httpClient
 	}
-	return createDefaultHTTPClient(timeout)
+	return createDefaultHTTPClient(timeout, opts...)
+}
+
+// clientSettings holds the tunable parts of the default HTTP client.
+type clientSettings struct {
+	TLSMinVersion uint16            // Minimum TLS version (default: TLS 1.3)
+	MaxRedirects  int               // Redirects followed before giving up; 0 disables redirects (default: 3)
+	SameHost      bool              // Reject redirects to a host other than the original request's
+	RootCAs       *x509.CertPool    // Trusted CAs; nil uses the system trust store
+	Certificates  []tls.Certificate // Client certificates presented for mutual TLS
+
+	InsecureSkipVerify bool // Skip server certificate verification (throwaway test proxies only)
+	DisableHTTP2       bool // Pin HTTP/1.1 even if HTTP/2 is enabled by default later
+
+	MaxIdleConns        int // Idle connections kept across all hosts; 0 means no limit (default: 10)
+	MaxIdleConnsPerHost int // Idle connections kept per host; 0 means Go's default of 2 (default: 5)
+
+	// Proxy selects the egress proxy for a request (default: http.ProxyFromEnvironment).
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// CheckDial reports whether the addresses dialed for a host must be
+	// checked against the blocked ranges; nil dials without checking.
+	CheckDial func(host string) bool
+}
+
+// clientOption customizes the default HTTP client.
+type clientOption func(*clientSettings)
+
+// withTLSMinVersion sets the minimum TLS version.
+func withTLSMinVersion(version uint16) clientOption {
+	return func(s *clientSettings) {
+		s.TLSMinVersion = version
+	}
+}
+
+// withMaxRedirects sets how many redirects are followed.
+func withMaxRedirects(n int) clientOption {
+	return func(s *clientSettings) {
+		s.MaxRedirects = n
+	}
+}
+
+// withSameHostRedirects rejects redirects that leave the original host.
+func withSameHostRedirects() clientOption {
+	return func(s *clientSettings) {
+		s.SameHost = true
+	}
+}
+
+// withRootCAs trusts the given CA pool instead of the system trust store.
+func withRootCAs(pool *x509.CertPool) clientOption {
+	return func(s *clientSettings) {
+		s.RootCAs = pool
+	}
+}
+
+// withClientCertificate presents cert to proxies requiring mutual TLS.
+func withClientCertificate(cert tls.Certificate) clientOption {
+	return func(s *clientSettings) {
+		s.Certificates = append(s.Certificates, cert)
+	}
+}
+
+// withIdleConns sets the connection pool limits of the transport.
+func withIdleConns(maxIdle, maxIdlePerHost int) clientOption {
+	return func(s *clientSettings) {
+		s.MaxIdleConns = maxIdle
+		s.MaxIdleConnsPerHost = maxIdlePerHost
+	}
+}
+
+// withProxy routes requests through the egress proxy chosen by proxy.
+func withProxy(proxyFunc func(*http.Request) (*url.URL, error)) clientOption {
+	return func(s *clientSettings) {
+		s.Proxy = proxyFunc
+	}
+}
+
+// withDialCheck rejects connections to blocked addresses for every host
+// check reports true for.
+func withDialCheck(check func(host string) bool) clientOption {
+	return func(s *clientSettings) {
+		s.CheckDial = check
+	}
+}
+
+// withoutHTTP2 pins the transport to HTTP/1.1. The default transport already
+// speaks HTTP/1.1 only, because a custom TLSClientConfig without
+// ForceAttemptHTTP2 disables Go's automatic HTTP/2 support.
+func withoutHTTP2() clientOption {
+	return func(s *clientSettings) {
+		s.DisableHTTP2 = true
+	}
+}
+
+// withInsecureSkipVerify disables server certificate verification.
+func withInsecureSkipVerify() clientOption {
+	return func(s *clientSettings) {
+		s.InsecureSkipVerify = true
+	}
 }
 
 // createDefaultHTTPClient creates a secure HTTP client with the given timeout.
-func createDefaultHTTPClient(timeout time.Duration) *http.Client {
+func createDefaultHTTPClient(timeout time.Duration, opts ...clientOption) *http.Client {
+	settings := clientSettings{
+		TLSMinVersion: tls.VersionTLS13,
+		MaxRedirects:  defaultMaxRedirects,
+
+		MaxIdleConns:        defaultMaxIdleConns,
+		MaxIdleConnsPerHost: defaultMaxIdleConnsPerHost,
+
+		Proxy: http.ProxyFromEnvironment,
+	}
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	transport := &http.Transport{
+		Proxy:               settings.Proxy,
+		MaxIdleConns:        settings.MaxIdleConns,
+		MaxIdleConnsPerHost: settings.MaxIdleConnsPerHost,
+		IdleConnTimeout:     90 * time.Second,
+		TLSClientConfig: &tls.Config{
+			MinVersion:   settings.TLSMinVersion,
+			RootCAs:      settings.RootCAs,
+			Certificates: settings.Certificates,
+			// Opt-in for throwaway test proxies; refused for public proxies.
+			InsecureSkipVerify: settings.InsecureSkipVerify,
+		},
+	}
+	if settings.CheckDial != nil {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		transport.DialContext = checkedDialContext(dialer, settings.CheckDial)
+	}
+	if settings.DisableHTTP2 {
+		// The transport above never negotiates HTTP/2 today. A non-nil, empty
+		// TLSNextProto keeps it that way, whatever ForceAttemptHTTP2 or
+		// GODEBUG say.
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
 	return &http.Client{
 		Timeout: timeout,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) >= 3 {
+			// via holds every request made so far, so the Nth redirect sees N entries.
+			if len(via) > settings.MaxRedirects {
 				return fmt.Errorf("too many redirects")
 			}
 			if req.URL.Scheme != "https" {
 				return fmt.Errorf("redirect to non-HTTPS URL not allowed")
 			}
+			// via[0] is the original request, whatever host the chain passed through.
+			if settings.SameHost && !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+				return fmt.Errorf("redirect to host %s not allowed (restrict_redirect_host)", req.URL.Host)
+			}
 			return nil
 		},
-		Transport: &http.Transport{
-			MaxIdleConns:        10,
-			MaxIdleConnsPerHost: 5,
-			IdleConnTimeout:     90 * time.Second,
-			TLSClientConfig: &tls.Config{
-				MinVersion: tls.VersionTLS13,
-			},
-		},
+		Transport: transport,
 	}
 }
 
-// Module path validation patterns.
-var (
-	// modulePathPattern validates Go module paths.
-	// Format: host/path (e.g., github.com/user/repo).
-	// Allows: alphanumerics, dots, dashes, underscores, and slashes.
-	modulePathPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*\.[a-zA-Z0-9._-]+(/[a-zA-Z0-9._-]+)+$`)
-
-	// simpleModulePattern for shorter paths like example.com/repo.
-	simpleModulePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*\.[a-zA-Z0-9._-]+/[a-zA-Z0-9._-]+$`)
-)
-
-// validateModulePath validates a Go module path.
+// validateModulePath validates a Go module path. Common mistakes get specific
+// messages first; the format itself is then checked with module.CheckPath,
+// exactly as the go command does.
 func validateModulePath(modulePath string) error {
 	if modulePath == "" {
 		return fmt.Errorf("module path cannot be empty")
@@ -93,23 +286,154 @@ func validateModulePath(modulePath string) error {
 		return fmt.Errorf("module path cannot start with '/'")
 	}
 
-	// Check for double slashes before regex (more specific error message).
+	// Check for double slashes (more specific error message).
 	if strings.Contains(modulePath, "//") {
 		return fmt.Errorf("module path cannot contain '//'")
 	}
 
-	// Check for valid Go module path format.
-	if !modulePathPattern.MatchString(modulePath) && !simpleModulePattern.MatchString(modulePath) {
+	// A bare host is a valid module path to the go command, but never a
+	// module anyone publishes through this plugin.
+	if !strings.Contains(modulePath, "/") {
 		return fmt.Errorf("invalid module path format: must be like 'github.com/user/repo'")
 	}
 
+	// gopkg.in serves a major version per path, so the path must name one.
+	if strings.HasPrefix(modulePath, "gopkg.in/") {
+		if _, ok := gopkgInMajor(modulePath); !ok {
+			return fmt.Errorf("invalid gopkg.in module path: must end in a .vN major version, like 'gopkg.in/yaml.v3'")
+		}
+	}
+
+	if err := checkPathElements(modulePath); err != nil {
+		return err
+	}
+	if err := module.CheckPath(modulePath); err != nil {
+		return fmt.Errorf("invalid module path format: %w", err)
+	}
+	return nil
+}
+
+// windowsReservedNames are device names that cannot be used as file names on
+// Windows, whatever their case or extension.
+var windowsReservedNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// checkPathElements reports the per-element mistakes module.CheckPath also
+// rejects, but with clearer messages. The host element must be lowercase, no
+// element may start or end with a dot, and no element may be a Windows
+// reserved name. Later elements may contain uppercase letters, as they may
+// for the go command, because the proxy case-encodes them (see
+// proxy.EscapePath).
+func checkPathElements(modulePath string) error {
+	elems := strings.Split(modulePath, "/")
+	if host := elems[0]; strings.ToLower(host) != host {
+		return fmt.Errorf("module path element %q must be lowercase", host)
+	}
+	for _, elem := range elems {
+		if strings.HasPrefix(elem, ".") {
+			return fmt.Errorf("module path element %q cannot start with a dot", elem)
+		}
+		if strings.HasSuffix(elem, ".") {
+			return fmt.Errorf("module path element %q cannot end with a dot", elem)
+		}
+		short, _, _ := strings.Cut(elem, ".")
+		for _, reserved := range windowsReservedNames {
+			if strings.EqualFold(short, reserved) {
+				return fmt.Errorf("module path element %q is a reserved name on Windows", elem)
+			}
+		}
+	}
+	return nil
+}
+
+// knownModuleHosts are module hosts accepted by strict_host regardless of
+// their top-level domain, in addition to knownVCSHosts.
+var knownModuleHosts = []string{
+	"gopkg.in", "golang.org", "google.golang.org", "go.googlesource.com",
+	"go.uber.org", "k8s.io", "sigs.k8s.io", "cloud.google.com",
+}
+
+// recognizedTLDs lists the top-level domains strict_host accepts: the generic
+// ones and the country codes commonly seen in module paths. It is deliberately
+// not the full IANA list; strict_host is meant to catch typos, and modules on
+// rarer domains simply leave it off.
+var recognizedTLDs = map[string]bool{
+	"com": true, "org": true, "net": true, "edu": true, "gov": true, "mil": true, "int": true,
+	"io": true, "dev": true, "app": true, "info": true, "biz": true, "cloud": true, "tech": true,
+	"xyz": true, "ai": true, "sh": true, "so": true, "co": true, "me": true, "page": true,
+	"ac": true, "at": true, "au": true, "be": true, "br": true, "ca": true, "ch": true,
+	"cn": true, "cz": true, "de": true, "dk": true, "es": true, "eu": true, "fi": true,
+	"fr": true, "hk": true, "ie": true, "il": true, "in": true, "it": true, "jp": true,
+	"kr": true, "nl": true, "no": true, "nz": true, "pl": true, "pt": true, "ru": true,
+	"se": true, "sg": true, "tw": true, "ua": true, "uk": true, "us": true, "za": true,
+}
+
+// checkStrictHost implements strict_host: the module path's host must be a
+// known module host or end in a recognized top-level domain, which catches
+// typos such as "github.con/user/repo" that are otherwise valid paths.
+func checkStrictHost(modulePath string) error {
+	host, _, _ := strings.Cut(modulePath, "/")
+	if slices.Contains(knownVCSHosts, host) || slices.Contains(knownModuleHosts, host) {
+		return nil
+	}
+	if tld := host[strings.LastIndex(host, ".")+1:]; !recognizedTLDs[tld] {
+		return fmt.Errorf("module path host %q does not end in a recognized top-level domain (strict_host)", host)
+	}
 	return nil
 }
 
 // validateProxyURL validates that a proxy URL is safe (SSRF protection).
 func validateProxyURL(proxyURL string) error {
-	// Only allow HTTPS.
-	if !strings.HasPrefix(proxyURL, "https://") {
+	return checkProxyURL(proxyURL, proxyPolicy{})
+}
+
+// proxyPolicy relaxes parts of the SSRF protection for trusted proxies.
+// The zero value applies the full protection.
+type proxyPolicy struct {
+	AllowPrivate bool     // Permit any private-network host
+	AllowedHosts []string // Exact hostnames permitted even if they look private
+	AllowHTTP    bool     // Permit plain http:// URLs (localhost and private hosts stay blocked)
+}
+
+// allowsPrivateHost reports whether host may bypass the private-network block.
+func (pp proxyPolicy) allowsPrivateHost(host string) bool {
+	if pp.AllowPrivate {
+		return true
+	}
+	host, err := asciiHost(host)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range pp.AllowedHosts {
+		if ascii, err := asciiHost(allowed); err == nil && ascii == host {
+			return true
+		}
+	}
+	return false
+}
+
+// asciiHost returns the lowercase ASCII (punycode) form of a hostname so that
+// Unicode and xn-- spellings of the same host are checked identically. IP
+// literals are returned lowercased without IDNA processing.
+func asciiHost(host string) (string, error) {
+	if net.ParseIP(host) != nil {
+		return strings.ToLower(host), nil
+	}
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(ascii), nil
+}
+
+// checkProxyURL validates a proxy URL under the given policy. HTTPS and the
+// localhost block are always enforced.
+func checkProxyURL(proxyURL string, policy proxyPolicy) error {
+	// Only allow HTTPS, unless the module is GOINSECURE-style insecure.
+	if !strings.HasPrefix(proxyURL, "https://") && !(policy.AllowHTTP && strings.HasPrefix(proxyURL, "http://")) {
 		return fmt.Errorf("proxy URL must use HTTPS")
 	}
 
@@ -124,33 +448,362 @@ func validateProxyURL(proxyURL string) error {
 		return fmt.Errorf("proxy URL must have a valid host")
 	}
 
+	// Normalise internationalised hostnames before any comparison.
+	host, err := asciiHost(parsed.Hostname())
+	if err != nil {
+		return fmt.Errorf("proxy URL has an invalid hostname: %w", err)
+	}
+
 	// SSRF protection: block localhost and private IPs.
-	host := strings.ToLower(parsed.Hostname())
 	if host == "localhost" || host == "127.0.0.1" || host == "::1" {
 		return fmt.Errorf("proxy URL cannot be localhost")
 	}
 
 	// Block common private network indicators.
-	if strings.HasPrefix(host, "10.") ||
-		strings.HasPrefix(host, "192.168.") ||
-		strings.HasPrefix(host, "172.") ||
-		strings.HasSuffix(host, ".local") ||
-		strings.HasSuffix(host, ".internal") {
+	if policy.allowsPrivateHost(host) {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		// Private, link-local, CGNAT, unique-local and unspecified ranges.
+		if isBlockedIP(ip) {
+			return fmt.Errorf("proxy URL cannot point to private network")
+		}
+	} else if strings.HasSuffix(host, ".local") || strings.HasSuffix(host, ".internal") {
 		return fmt.Errorf("proxy URL cannot point to private network")
 	}
 
 	return nil
 }
 
+// proxyList is the parsed form of a GOPROXY-style proxy_url value.
+type proxyList struct {
+	URLs    []string // Usable proxy URLs in fallback order
+	Invalid []string // Entries rejected by checkProxyURL, with the reason
+	Off     bool     // List was terminated by "off"
+	Direct  bool     // List was terminated by "direct"
+}
+
+// parseProxyList splits a GOPROXY-style value into its entries. Both the ","
+// and "|" separators are accepted; either way the plugin falls through to the
+// next proxy on failure. As with the go command, "direct" and "off" terminate
+// the list and anything after them is ignored. Entries that fail
+// checkProxyURL are filtered out.
+func parseProxyList(raw string, policy proxyPolicy) proxyList {
+	var list proxyList
+	separators := func(r rune) bool { return r == ',' || r == '|' }
+	for _, entry := range strings.FieldsFunc(raw, separators) {
+		entry = strings.TrimSpace(entry)
+		switch entry {
+		case "":
+			continue
+		case proxyOff:
+			list.Off = true
+			return list
+		case proxyDirect:
+			list.Direct = true
+			return list
+		}
+
+		if err := checkProxyURL(entry, policy); err != nil {
+			list.Invalid = append(list.Invalid, fmt.Sprintf("%s: %v", entry, err))
+			continue
+		}
+		list.URLs = append(list.URLs, entry)
+	}
+	return list
+}
+
 // GoModPlugin implements the Publish Go modules to proxy.golang.org plugin.
 type GoModPlugin struct{}
 
 // Config holds the plugin configuration.
 type Config struct {
-	ModulePath string // Full Go module path (e.g., "github.com/user/repo")
-	ProxyURL   string // Go module proxy URL (default: "https://proxy.golang.org")
-	Private    bool   // If true, skip proxy notification (private modules)
-	Timeout    int    // Request timeout in seconds (default: 30)
+	ModulePath  string // Full Go module path (e.g., "github.com/user/repo")
+	GoModPath   string // go.mod used to detect ModulePath when it is not configured (default: "./go.mod")
+	VersionFile string // File holding the release version, read when the release context has none
+	StrictHost  bool   // Require the module path host to be a known host or end in a recognized TLD
+	ProxyURL    string // Comma-separated GOPROXY-style proxy list (default: "https://proxy.golang.org")
+	Private     bool   // If true, skip proxy notification (private modules)
+	PrivateSet  bool   // Private was set explicitly, disabling pattern detection
+
+	PrivatePatterns string // Comma-separated GOPRIVATE-style globs marking modules as private (default: $GOPRIVATE)
+	Timeout         int    // Per-attempt request timeout in seconds; retries each get a fresh one (default: 30)
+
+	TotalTimeout int // Deadline in seconds for the whole notification including retries and verification (0 = none)
+
+	ModulePaths   []string // All configured module paths in order; ModulePath is the first
+	FromWorkspace bool     // Detect ModulePaths from the go.work next to GoModPath when none are configured
+	Versions      []string // Versions to publish in one run, overriding the release context's version
+
+	MaxConcurrency  int // Modules notified in parallel when there are several (default: 4)
+	RateLimitPerSec int // Module notifications started per second; 0 means unlimited
+
+	MaxRetries       int    // Additional attempts after the first (default: 3)
+	RetryBackoffMs   int    // Initial delay between attempts in milliseconds, doubled per retry (default: 1000)
+	RetryBodyPattern string // Regex matched against success bodies that signal "not ready yet"
+
+	DryRunVerify       bool // In dry runs, still GET @v/list to check the proxy and version
+	DryRunConnectivity bool // In dry runs, send a HEAD request to the proxy root and report reachability
+
+	NotifySumDB     bool   // Look the version up in the checksum database after notifying
+	VerifySumDB     bool   // Fail unless the checksum database serves a record for the version
+	SumDBURL        string // Checksum database URL (default: "https://sum.golang.org")
+	NoSumDBPatterns string // Comma-separated globs of modules excluded from NotifySumDB (default: $GONOSUMDB)
+
+	Verify        bool // Poll the proxy until it reports the published version
+	VerifyTimeout int  // Maximum time to wait for verification in seconds (default: 60)
+	NotifyLatest  bool // Query @latest after notifying so the proxy refreshes its latest pointer
+	WarmPkgsite   bool // Request the version's pkg.go.dev page after notifying to trigger rendering
+
+	LogLevel string // Structured log verbosity: debug, info, warn or error (default: error)
+
+	ProxyToken        string // Bearer token sent to authenticated proxies; never logged or echoed
+	AllowPrivateProxy bool   // Permit private-network proxy hosts (HTTPS and the localhost block still apply)
+	ProxyUsername     string // HTTP Basic auth user, used together with ProxyPassword
+	ProxyPassword     string // HTTP Basic auth password; never logged or echoed
+
+	AllowedHosts []string // Proxy hostnames exempt from the private-network block
+
+	InsecurePatterns string // Comma-separated GOINSECURE-style globs of modules allowed to use http:// proxies (default: $GOINSECURE)
+
+	UserAgent string            // User-Agent sent with proxy requests (default: "relicta-gomod-plugin/2.0.0")
+	Headers   map[string]string // Extra headers sent with proxy requests; Host is never overridden
+
+	SkipDNSCheck bool // Skip resolving proxy hosts to check their addresses (air-gapped setups)
+
+	CheckMod      bool // Confirm the proxy's go.mod for the version declares ModulePath
+	CheckZip      bool // Confirm the proxy serves a non-empty zip for the version
+	SoftNotFound  bool // Succeed with a warning when the proxy still reports 404 after all retries
+	DisableCache  bool // Send every notification even if an identical one succeeded in this Execute call
+	FailIfExists  bool // Fail if the proxy's @v/list already contains the version
+	CheckExisting bool // Skip notifying if the proxy's @v/list already contains the version
+	AutoVersion   bool // Use the proxy's @latest version when the release has none
+	AllowPseudo   bool // Accept pseudo-versions of untagged commits (default: true)
+	CheckVanity   bool // Confirm a vanity module host serves a valid go-import meta tag
+
+	TLSMinVersion uint16 // Minimum TLS version for proxy connections (default: TLS 1.3)
+	MaxRedirects  int    // HTTPS redirects followed per request; 0 disables redirects (default: 3)
+	CACertFile    string // PEM file with CAs trusted for proxy connections instead of the system store
+
+	RestrictRedirectHost bool // Only follow redirects that stay on the original request's host
+	DisableHTTP2         bool // Pin proxy connections to HTTP/1.1 (already the default)
+
+	ClientCertFile string // PEM client certificate for mutual TLS, used with ClientKeyFile
+	ClientKeyFile  string // PEM private key matching ClientCertFile
+
+	InsecureSkipVerify bool // Skip TLS certificate verification; refused for public proxies
+
+	MaxIdleConns        int // Idle connections kept across all proxy hosts (default: 10)
+	MaxIdleConnsPerHost int // Idle connections kept per proxy host (default: 5)
+
+	HTTPSProxy string // Egress proxy for HTTPS requests, overriding $HTTPS_PROXY
+	NoProxy    string // Hosts reached without the egress proxy, overriding $NO_PROXY
+
+	cache *requestCache // Successful notifications of the current Execute call; nil disables caching
+}
+
+// clientOptions returns the HTTP client options derived from the
+// configuration, loading any referenced certificate files.
+func (cfg *Config) clientOptions() ([]clientOption, error) {
+	opts := []clientOption{
+		withMaxRedirects(cfg.MaxRedirects),
+		withIdleConns(cfg.MaxIdleConns, cfg.MaxIdleConnsPerHost),
+		withProxy(cfg.egressProxy()),
+	}
+	if !cfg.SkipDNSCheck {
+		opts = append(opts, withDialCheck(cfg.dialChecked))
+	}
+	if cfg.TLSMinVersion != 0 {
+		opts = append(opts, withTLSMinVersion(cfg.TLSMinVersion))
+	}
+	if cfg.RestrictRedirectHost {
+		opts = append(opts, withSameHostRedirects())
+	}
+	if cfg.DisableHTTP2 {
+		opts = append(opts, withoutHTTP2())
+	}
+	if cfg.CACertFile != "" {
+		pool, err := loadCertPool(cfg.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, withRootCAs(pool))
+	}
+	// Refuse to connect without the certificate a half-configured mTLS
+	// setup was meant to present.
+	if (cfg.ClientCertFile == "") != (cfg.ClientKeyFile == "") {
+		return nil, fmt.Errorf("client_cert_file and client_key_file must be set together")
+	}
+	if cfg.ClientCertFile != "" {
+		cert, err := loadClientCertificate(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, withClientCertificate(cert))
+	}
+	if cfg.InsecureSkipVerify {
+		opts = append(opts, withInsecureSkipVerify())
+	}
+	return opts, nil
+}
+
+// newHTTPClient returns the HTTP client configured for cfg.
+func (cfg *Config) newHTTPClient() (HTTPClient, error) {
+	opts, err := cfg.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return getHTTPClient(time.Duration(cfg.Timeout)*time.Second, opts...), nil
+}
+
+// egressProxy returns the function choosing the egress proxy for a request.
+// Like http.ProxyFromEnvironment it honours HTTPS_PROXY, HTTP_PROXY and
+// NO_PROXY, but it reads them on every call rather than once per process, and
+// the https_proxy and no_proxy options take precedence over the environment.
+func (cfg *Config) egressProxy() func(*http.Request) (*url.URL, error) {
+	proxyFunc := cfg.egressProxyConfig().ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
+// egressProxyConfig returns the environment's egress proxy settings with the
+// https_proxy and no_proxy options applied.
+func (cfg *Config) egressProxyConfig() *httpproxy.Config {
+	proxyConfig := httpproxy.FromEnvironment()
+	if cfg.HTTPSProxy != "" {
+		proxyConfig.HTTPSProxy = cfg.HTTPSProxy
+	}
+	if cfg.NoProxy != "" {
+		proxyConfig.NoProxy = cfg.NoProxy
+	}
+	return proxyConfig
+}
+
+// dialChecked reports whether connections to host must be checked against
+// the blocked ranges. Hosts trusted by allow_private_proxy or allowed_hosts
+// are not, and neither is the egress proxy: the operator chose it, and it
+// commonly lives on a private network.
+func (cfg *Config) dialChecked(host string) bool {
+	if cfg.proxyPolicy().allowsPrivateHost(host) {
+		return false
+	}
+	proxyConfig := cfg.egressProxyConfig()
+	for _, egress := range []string{proxyConfig.HTTPSProxy, proxyConfig.HTTPProxy} {
+		if egress == "" {
+			continue
+		}
+		if !strings.Contains(egress, "://") {
+			egress = "http://" + egress
+		}
+		if parsed, err := url.Parse(egress); err == nil && strings.EqualFold(parsed.Hostname(), host) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrivate reports whether the module should skip proxy notification: an
+// explicit private setting wins, otherwise the module path is matched against
+// the private patterns.
+func (cfg *Config) isPrivate() bool {
+	_, private := cfg.privatePattern()
+	return private
+}
+
+// privatePattern is like isPrivate but also returns the pattern that marked
+// the module private, or "" if it was the explicit private setting.
+func (cfg *Config) privatePattern() (string, bool) {
+	if cfg.PrivateSet {
+		return "", cfg.Private
+	}
+	return matchPrefixPatterns(cfg.PrivatePatterns, cfg.ModulePath)
+}
+
+// checkModulePath validates a module path, additionally applying
+// checkStrictHost when strict_host is set.
+func (cfg *Config) checkModulePath(modulePath string) error {
+	if err := validateModulePath(modulePath); err != nil {
+		return err
+	}
+	if cfg.StrictHost {
+		return checkStrictHost(modulePath)
+	}
+	return nil
+}
+
+// proxyPolicy returns the SSRF policy derived from the configuration.
+func (cfg *Config) proxyPolicy() proxyPolicy {
+	_, insecure := matchPrefixPatterns(cfg.InsecurePatterns, cfg.ModulePath)
+	return proxyPolicy{
+		AllowPrivate: cfg.AllowPrivateProxy,
+		AllowedHosts: cfg.AllowedHosts,
+		AllowHTTP:    insecure,
+	}
+}
+
+// redact removes configured secrets from a string before it leaves the plugin.
+func (cfg *Config) redact(value string) string {
+	secrets := []string{cfg.ProxyToken, cfg.ProxyPassword}
+	if cfg.ProxyUsername != "" && cfg.ProxyPassword != "" {
+		// The encoded form is what a proxy would echo from the header.
+		secrets = append(secrets, base64.StdEncoding.EncodeToString([]byte(cfg.ProxyUsername+":"+cfg.ProxyPassword)))
+	}
+
+	for _, secret := range secrets {
+		if secret != "" {
+			value = strings.ReplaceAll(value, secret, "[REDACTED]")
+		}
+	}
+	return value
+}
+
+// redactResponse redacts configured secrets from a response's error, message
+// and outputs, including nested per-module and per-version results.
+func (cfg *Config) redactResponse(resp *plugin.ExecuteResponse) {
+	if resp == nil {
+		return
+	}
+	resp.Error = cfg.redact(resp.Error)
+	resp.Message = cfg.redact(resp.Message)
+	for key, value := range resp.Outputs {
+		resp.Outputs[key] = cfg.redactValue(value)
+	}
+}
+
+// redactValue returns value with secrets redacted from every string it
+// contains. Maps and slices are copied rather than modified in place.
+func (cfg *Config) redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return cfg.redact(v)
+	case []string:
+		redacted := make([]string, len(v))
+		for i, s := range v {
+			redacted[i] = cfg.redact(s)
+		}
+		return redacted
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for k, s := range v {
+			redacted[k] = cfg.redact(s)
+		}
+		return redacted
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for k, nested := range v {
+			redacted[k] = cfg.redactValue(nested)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, nested := range v {
+			redacted[i] = cfg.redactValue(nested)
+		}
+		return redacted
+	default:
+		return value
+	}
 }
 
 // GetInfo returns plugin metadata.
@@ -161,28 +814,53 @@ func (p *GoModPlugin) GetInfo() plugin.Info {
 		Description: "Publish Go modules to proxy.golang.org",
 		Author:      "Relicta Team",
 		Hooks: []plugin.Hook{
+			plugin.HookPrePublish,
 			plugin.HookPostPublish,
+			plugin.HookOnError,
 		},
-		ConfigSchema: `{
-			"type": "object",
-			"properties": {
-				"module_path": {"type": "string", "description": "Full Go module path (e.g., github.com/user/repo, or use GO_MODULE_PATH env)"},
-				"proxy_url": {"type": "string", "description": "Go module proxy URL (default: https://proxy.golang.org)"},
-				"private": {"type": "boolean", "description": "Skip proxy notification for private modules", "default": false},
-				"timeout": {"type": "integer", "description": "Request timeout in seconds", "default": 30}
-			},
-			"required": ["module_path"]
-		}`,
+		ConfigSchema: p.ConfigSchema(),
 	}
 }
 
 // Execute runs the plugin for a given hook.
 func (p *GoModPlugin) Execute(ctx context.Context, req plugin.ExecuteRequest) (*plugin.ExecuteResponse, error) {
 	cfg := p.parseConfig(req.Config)
+	if !cfg.DisableCache {
+		cfg.cache = newRequestCache()
+	}
+	if len(cfg.ModulePaths) == 0 {
+		// The go.mod may live in the release workspace rather than our cwd.
+		if detected, err := detectModulePaths(cfg.GoModPath, cfg.FromWorkspace, req.Context.Environment); err == nil {
+			cfg.ModulePath = detected[0]
+			cfg.ModulePaths = detected
+		}
+	}
+
+	releaseCtx := req.Context
+	if cfg.VersionFile != "" && releaseCtx.Version == "" {
+		version, err := readVersionFile(cfg.VersionFile, releaseCtx.Environment)
+		switch {
+		case err == nil:
+			releaseCtx.Version = version
+		case req.Hook == plugin.HookPrePublish || req.Hook == plugin.HookPostPublish:
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("cannot read version_file: %v", err),
+			}, nil
+		}
+	}
 
 	switch req.Hook {
+	case plugin.HookPrePublish:
+		resp, err := p.prePublish(ctx, cfg, releaseCtx)
+		cfg.redactResponse(resp)
+		return resp, err
 	case plugin.HookPostPublish:
-		return p.postPublish(ctx, cfg, req.Context, req.DryRun)
+		resp, err := p.postPublish(ctx, cfg, releaseCtx, req.DryRun)
+		cfg.redactResponse(resp)
+		return resp, err
+	case plugin.HookOnError:
+		return p.onError(cfg, releaseCtx), nil
 	default:
 		return &plugin.ExecuteResponse{
 			Success: true,
@@ -191,154 +869,817 @@ func (p *GoModPlugin) Execute(ctx context.Context, req plugin.ExecuteRequest) (*
 	}
 }
 
+// errTotalTimeout is the cancellation cause of the total_timeout deadline.
+var errTotalTimeout = errors.New("total_timeout exceeded")
+
 func (p *GoModPlugin) postPublish(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
-	// Validate module path.
-	if err := validateModulePath(cfg.ModulePath); err != nil {
-		return &plugin.ExecuteResponse{
-			Success: false,
-			Error:   fmt.Sprintf("invalid module path: %v", err),
-		}, nil
+	// total_timeout bounds everything below, while timeout still bounds
+	// each individual request.
+	if cfg.TotalTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, time.Duration(cfg.TotalTimeout)*time.Second, errTotalTimeout)
+		defer cancel()
 	}
 
-	// Check if this is a private module.
-	if cfg.Private {
-		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: "Skipping proxy notification for private module",
-			Outputs: map[string]any{
-				"module_path": cfg.ModulePath,
-				"private":     true,
-				"skipped":     true,
-			},
-		}, nil
+	if cfg.InsecureSkipVerify {
+		if host := publicProxyHost(cfg.ProxyURL); host != "" {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("insecure_skip_verify cannot be used with the public proxy %s", host),
+			}, nil
+		}
 	}
 
-	// Validate proxy URL.
-	if err := validateProxyURL(cfg.ProxyURL); err != nil {
-		return &plugin.ExecuteResponse{
-			Success: false,
-			Error:   fmt.Sprintf("invalid proxy URL: %v", err),
-		}, nil
+	var resp *plugin.ExecuteResponse
+	var err error
+	if len(cfg.Versions) > 0 {
+		resp, err = p.publishVersions(ctx, cfg, releaseCtx, dryRun)
+	} else {
+		resp, err = p.publishRelease(ctx, cfg, releaseCtx, dryRun)
+	}
+
+	if resp != nil {
+		if host := publicProxyHost(cfg.ProxyURL); host != "" {
+			if leaked := cfg.likelyInternalModules(); len(leaked) > 0 {
+				resp.Message = withWarnings(resp.Message, []string{fmt.Sprintf(
+					"%s looks internal and was not marked private; its name is sent to the public proxy %s",
+					strings.Join(leaked, ", "), host)})
+				if resp.Outputs == nil {
+					resp.Outputs = make(map[string]any)
+				}
+				resp.Outputs["leak_warning"] = true
+			}
+		}
 	}
 
-	// Get version from release context.
-	version := releaseCtx.Version
-	if version == "" {
-		version = releaseCtx.TagName
+	// Only blame total_timeout when our own deadline fired, not the host's.
+	if resp != nil && !resp.Success && errors.Is(context.Cause(ctx), errTotalTimeout) {
+		resp.Error = fmt.Sprintf("%s (overall deadline expired: total_timeout of %ds exceeded)", resp.Error, cfg.TotalTimeout)
 	}
-	if version == "" {
-		return &plugin.ExecuteResponse{
-			Success: false,
-			Error:   "version is required for proxy notification",
-		}, nil
+	if resp != nil && cfg.InsecureSkipVerify {
+		resp.Message = fmt.Sprintf("%s: %s", insecureSkipVerifyWarning, resp.Message)
+	}
+	return resp, err
+}
+
+// publishRelease notifies the proxy for every configured module at the
+// release context's version.
+func (p *GoModPlugin) publishRelease(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	if len(cfg.ModulePaths) > 1 {
+		return p.publishModules(ctx, cfg, releaseCtx, dryRun)
 	}
+	return p.publishModule(ctx, cfg, releaseCtx, dryRun)
+}
+
+// publishVersions publishes each configured version in turn and aggregates
+// the results. Each version is normalized and validated on its own, and the
+// run only succeeds if every version does.
+func (p *GoModPlugin) publishVersions(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	statuses := make(map[string]string, len(cfg.Versions))
+	results := make(map[string]any, len(cfg.Versions))
+	messages := make([]string, 0, len(cfg.Versions))
+	var failures []string
+
+	for _, version := range cfg.Versions {
+		versionCtx := releaseCtx
+		versionCtx.Version = version
+		versionCtx.TagName = ""
 
-	// Ensure version has v prefix for Go modules.
-	if !strings.HasPrefix(version, "v") {
-		version = "v" + version
+		resp, err := p.publishRelease(ctx, cfg, versionCtx, dryRun)
+		if err != nil {
+			return nil, err
+		}
+
+		key := proxy.NormalizeVersion(version)
+		statuses[key] = moduleStatus(resp)
+		results[key] = resp.Outputs
+		if resp.Success {
+			messages = append(messages, resp.Message)
+		} else {
+			failures = append(failures, fmt.Sprintf("%s: %s", key, resp.Error))
+		}
 	}
 
-	if dryRun {
-		return &plugin.ExecuteResponse{
-			Success: true,
-			Message: fmt.Sprintf("Would notify Go module proxy for %s@%s", cfg.ModulePath, version),
-			Outputs: map[string]any{
-				"module_path": cfg.ModulePath,
-				"version":     version,
-				"proxy_url":   cfg.ProxyURL,
-			},
-		}, nil
+	outputs := map[string]any{
+		"versions": statuses,
+		"results":  results,
 	}
 
-	// Trigger proxy to index the module version.
-	if err := p.triggerProxyIndex(ctx, cfg, version); err != nil {
+	if len(failures) > 0 {
 		return &plugin.ExecuteResponse{
 			Success: false,
-			Error:   fmt.Sprintf("failed to notify proxy: %v", err),
+			Message: fmt.Sprintf("Published %d of %d versions", len(cfg.Versions)-len(failures), len(cfg.Versions)),
+			Error:   fmt.Sprintf("failed to publish %d of %d versions: %s", len(failures), len(cfg.Versions), strings.Join(failures, "; ")),
+			Outputs: outputs,
 		}, nil
 	}
 
 	return &plugin.ExecuteResponse{
 		Success: true,
-		Message: fmt.Sprintf("Go module proxy notified for %s@%s", cfg.ModulePath, version),
-		Outputs: map[string]any{
-			"module_path": cfg.ModulePath,
-			"version":     version,
-			"proxy_url":   cfg.ProxyURL,
-		},
+		Message: strings.Join(messages, "; "),
+		Outputs: outputs,
 	}, nil
 }
 
-// triggerProxyIndex sends a request to the Go module proxy to index the version.
-func (p *GoModPlugin) triggerProxyIndex(ctx context.Context, cfg *Config, version string) error {
-	// Build the proxy URL: {proxy_url}/{module}/@v/{version}.info
-	// URL-encode the module path for safety.
-	encodedModule := url.PathEscape(cfg.ModulePath)
-	// Replace %2F back to / for proper module path format in URL.
-	encodedModule = strings.ReplaceAll(encodedModule, "%2F", "/")
-
-	proxyRequestURL := fmt.Sprintf("%s/%s/@v/%s.info",
-		strings.TrimSuffix(cfg.ProxyURL, "/"),
-		encodedModule,
-		version,
-	)
+// internalHostHints are host labels that suggest a module is hosted on an
+// internal network rather than a public code host.
+var internalHostHints = []string{"internal", "corp"}
 
-	// Validate the final URL.
-	if err := validateProxyURL(proxyRequestURL); err != nil {
-		return fmt.Errorf("invalid request URL: %w", err)
+// likelyInternalModules returns the configured, non-private modules that look
+// internal: their host contains an internalHostHints label or ends in .lan,
+// or they match private_patterns while private was explicitly set to false.
+func (cfg *Config) likelyInternalModules() []string {
+	modulePaths := cfg.ModulePaths
+	if len(modulePaths) == 0 {
+		modulePaths = []string{cfg.ModulePath}
+	}
+
+	var internal []string
+	for _, modulePath := range modulePaths {
+		moduleCfg := *cfg
+		moduleCfg.ModulePath = modulePath
+		if moduleCfg.isPrivate() {
+			continue
+		}
+		if _, matched := matchPrefixPatterns(cfg.PrivatePatterns, modulePath); matched || isInternalHost(modulePath) {
+			internal = append(internal, modulePath)
+		}
+	}
+	return internal
+}
+
+// isInternalHost reports whether the host of modulePath looks like an
+// internal one, such as git.corp.example or github.company-internal.example.
+func isInternalHost(modulePath string) bool {
+	host, _, _ := strings.Cut(strings.ToLower(modulePath), "/")
+	if strings.HasSuffix(host, ".lan") {
+		return true
+	}
+	labels := strings.FieldsFunc(host, func(r rune) bool { return r == '.' || r == '-' })
+	for _, label := range labels {
+		if slices.Contains(internalHostHints, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// publicProxyHost returns the first well-known public proxy host in a
+// GOPROXY-style list, or "" if there is none.
+func publicProxyHost(rawList string) string {
+	for _, entry := range strings.FieldsFunc(rawList, func(r rune) bool { return r == ',' || r == '|' }) {
+		parsed, err := url.Parse(strings.TrimSpace(entry))
+		if err != nil {
+			continue
+		}
+		host, err := asciiHost(parsed.Hostname())
+		if err != nil {
+			continue
+		}
+		for _, public := range publicProxyHosts {
+			if host == public {
+				return host
+			}
+		}
+	}
+	return ""
+}
+
+// publishModules notifies the proxy for every configured module path and
+// aggregates the per-module results. The run only succeeds if every module does.
+// Up to MaxConcurrency modules are notified in parallel, and at most
+// RateLimitPerSec notifications are started per second.
+func (p *GoModPlugin) publishModules(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	responses := make([]*plugin.ExecuteResponse, len(cfg.ModulePaths))
+	errs := make([]error, len(cfg.ModulePaths))
+	limiter := newRateLimiter(cfg.RateLimitPerSec)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(cfg.MaxConcurrency, 1), len(cfg.ModulePaths)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := limiter.wait(ctx); err != nil {
+					responses[i] = &plugin.ExecuteResponse{
+						Success: false,
+						Error:   fmt.Sprintf("rate limit wait aborted: %v", err),
+					}
+					continue
+				}
+
+				moduleCfg := *cfg
+				moduleCfg.ModulePath = cfg.ModulePaths[i]
+				moduleCfg.ModulePaths = nil
+				responses[i], errs[i] = p.publishModule(ctx, &moduleCfg, releaseCtx, dryRun)
+			}
+		}()
+	}
+	for i := range cfg.ModulePaths {
+		jobs <- i
 	}
+	close(jobs)
+	wg.Wait()
 
-	// Create HTTP request.
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, proxyRequestURL, nil)
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]any, len(cfg.ModulePaths))
+	statuses := make(map[string]string, len(cfg.ModulePaths))
+	messages := make([]string, 0, len(cfg.ModulePaths))
+	var failures []string
+
+	for i, modulePath := range cfg.ModulePaths {
+		resp := responses[i]
+		result := make(map[string]any, len(resp.Outputs)+2)
+		for k, v := range resp.Outputs {
+			result[k] = v
+		}
+		result["success"] = resp.Success
+		statuses[modulePath] = moduleStatus(resp)
+		if resp.Success {
+			messages = append(messages, resp.Message)
+		} else {
+			result["error"] = cfg.redact(resp.Error)
+			failures = append(failures, fmt.Sprintf("%s: %s", modulePath, resp.Error))
+		}
+		results[modulePath] = result
+	}
+
+	outputs := map[string]any{
+		"module_paths": cfg.ModulePaths,
+		"results":      results,
+		"statuses":     statuses,
+	}
+
+	if len(failures) > 0 {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Message: fmt.Sprintf("Notified %d of %d modules", len(cfg.ModulePaths)-len(failures), len(cfg.ModulePaths)),
+			Error:   fmt.Sprintf("failed to notify %d of %d modules: %s", len(failures), len(cfg.ModulePaths), strings.Join(failures, "; ")),
+			Outputs: outputs,
+		}, nil
+	}
+
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: strings.Join(messages, "; "),
+		Outputs: outputs,
+	}, nil
+}
+
+// moduleStatus summarizes a single module's outcome as "notified",
+// "skipped" or "failed".
+func moduleStatus(resp *plugin.ExecuteResponse) string {
+	switch {
+	case !resp.Success:
+		return "failed"
+	case resp.Outputs["skipped"] == true:
+		return "skipped"
+	default:
+		return "notified"
+	}
+}
+
+// publishModule notifies the proxy for a single module path.
+func (p *GoModPlugin) publishModule(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext, dryRun bool) (*plugin.ExecuteResponse, error) {
+	// Validate module path.
+	if err := cfg.checkModulePath(cfg.ModulePath); err != nil {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("invalid module path: %v", err),
+		}, nil
+	}
+
+	// Check if this is a private module.
+	if pattern, private := cfg.privatePattern(); private {
+		outputs := map[string]any{
+			"module_path": cfg.ModulePath,
+			"private":     true,
+			"skipped":     true,
+			"skip_reason": "private",
+		}
+		if pattern != "" {
+			outputs["private_pattern"] = pattern
+		}
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Skipping proxy notification for private module",
+			Outputs: outputs,
+		}, nil
+	}
+
+	// Resolve the proxy list. An empty list is only acceptable when it was
+	// explicitly turned off or set to "direct"; if every configured entry was
+	// rejected, that is a configuration error.
+	proxies := parseProxyList(cfg.ProxyURL, cfg.proxyPolicy())
+	if len(proxies.URLs) == 0 {
+		switch {
+		case len(proxies.Invalid) > 0:
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("invalid proxy URL: %s", strings.Join(proxies.Invalid, "; ")),
+			}, nil
+		case proxies.Off:
+			return &plugin.ExecuteResponse{
+				Success: true,
+				Message: "Skipping proxy notification: proxy is set to off",
+				Outputs: map[string]any{
+					"module_path": cfg.ModulePath,
+					"skipped":     true,
+					"skip_reason": "proxy_off",
+				},
+			}, nil
+		case proxies.Direct:
+			return &plugin.ExecuteResponse{
+				Success: true,
+				Message: "Skipping proxy notification: proxy is set to direct, no proxy to notify",
+				Outputs: map[string]any{
+					"module_path": cfg.ModulePath,
+					"skipped":     true,
+					"skip_reason": "proxy_direct",
+				},
+			}, nil
+		default:
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   "invalid proxy URL: no proxy URLs configured",
+			}, nil
+		}
+	}
+
+	// Without a version from the release, optionally take the one the proxy
+	// reports as latest. If that fails, the usual "version is required"
+	// error below applies.
+	if cfg.AutoVersion && releaseCtx.Version == "" && releaseCtx.TagName == "" {
+		latest, err := p.detectLatestVersion(ctx, cfg)
+		if err != nil {
+			cfg.logf(logLevelWarn, "could not detect the module version", map[string]any{
+				"module": cfg.ModulePath,
+				"error":  cfg.redact(err.Error()),
+			})
+		}
+		releaseCtx.Version = latest
+	}
+
+	// Reject malformed versions before wasting a round trip to the proxy.
+	version, err := releaseVersion(cfg.ModulePath, releaseCtx)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
 	}
 
-	req.Header.Set("User-Agent", "relicta-gomod-plugin/2.0.0")
+	if !cfg.AllowPseudo && isPseudoVersion(version) {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("refusing to publish pseudo-version %s: allow_pseudo is false, tag a release instead", version),
+		}, nil
+	}
 
-	// Get HTTP client with configured timeout.
-	timeout := time.Duration(cfg.Timeout) * time.Second
-	client := getHTTPClient(timeout)
+	// Optionally refuse to re-publish a version the proxy already has.
+	if cfg.FailIfExists {
+		if err := p.checkVersionAbsent(ctx, cfg, version); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, nil
+		}
+	}
 
-	// Send request.
-	resp, err := client.Do(req)
+	// Optionally skip versions the proxy already has. The listing is only an
+	// optimisation, so if it fails the proxy is notified as usual.
+	if cfg.CheckExisting {
+		versions, err := p.listVersions(ctx, cfg)
+		switch {
+		case err != nil:
+			cfg.logf(logLevelWarn, "could not check for an existing version", map[string]any{
+				"module":  cfg.ModulePath,
+				"version": version,
+				"error":   cfg.redact(err.Error()),
+			})
+		case slices.Contains(versions, version):
+			return &plugin.ExecuteResponse{
+				Success: true,
+				Message: fmt.Sprintf("%s@%s already indexed by the Go module proxy", cfg.ModulePath, version),
+				Outputs: map[string]any{
+					"module_path":     cfg.ModulePath,
+					"version":         version,
+					"module_version":  cfg.ModulePath + "@" + version,
+					"proxy_url":       cfg.ProxyURL,
+					"already_indexed": true,
+				},
+			}, nil
+		}
+	}
+
+	// Optionally confirm a vanity import path resolves to a repository before
+	// the proxy tries to fetch it from there.
+	var vanity *goImport
+	if cfg.CheckVanity && isVanityPath(cfg.ModulePath) {
+		if vanity, err = p.checkVanity(ctx, cfg); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("vanity import check failed: %v", err),
+			}, nil
+		}
+	}
+
+	if dryRun {
+		message := fmt.Sprintf("Would notify Go module proxy for %s@%s", cfg.ModulePath, version)
+		outputs := map[string]any{
+			"module_path":    cfg.ModulePath,
+			"version":        version,
+			"module_version": cfg.ModulePath + "@" + version,
+			"proxy_url":      cfg.ProxyURL,
+		}
+		vanity.addOutputs(outputs)
+		if cfg.DryRunVerify {
+			finding, reachable := p.dryRunCheck(ctx, cfg, version)
+			message = fmt.Sprintf("%s (dry-run check: %s)", message, finding)
+			outputs["proxy_reachable"] = reachable
+		}
+		if cfg.DryRunConnectivity {
+			finding, reachable, tlsVersion := p.dryRunConnectivity(ctx, cfg)
+			message = fmt.Sprintf("%s (connectivity check: %s)", message, finding)
+			outputs["reachable"] = reachable
+			outputs["tls_version"] = tlsVersion
+		}
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: message,
+			Outputs: outputs,
+		}, nil
+	}
+
+	// Trigger proxy to index the module version.
+	result, err := p.triggerProxyIndex(ctx, cfg, version)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		resp := &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to notify proxy: %v", err),
+		}
+		if result != nil {
+			resp.Outputs = result.outputs()
+		} else {
+			resp.Outputs = map[string]any{}
+		}
+		// error_code predates error_kind and carries the same value.
+		var proxyErr *proxy.Error
+		if errors.As(err, &proxyErr) {
+			resp.Outputs["error_kind"] = proxyErr.Kind
+			resp.Outputs["error_code"] = proxyErr.Kind
+			resp.Outputs["retryable"] = proxyErr.Retryable
+
+			// With soft_not_found a version the proxy cannot see yet is left
+			// to propagate on its own instead of failing the release.
+			if cfg.SoftNotFound && proxyErr.Kind == "not_found" {
+				resp.Success = true
+				resp.Error = ""
+				resp.Message = withWarnings(fmt.Sprintf("Go module proxy notification pending for %s@%s", cfg.ModulePath, version),
+					[]string{fmt.Sprintf("proxy does not serve the version yet: %v", err)})
+				resp.Outputs["module_path"] = cfg.ModulePath
+				resp.Outputs["version"] = version
+				resp.Outputs["module_version"] = cfg.ModulePath + "@" + version
+				resp.Outputs["proxy_url"] = cfg.ProxyURL
+				resp.Outputs["pending"] = true
+			}
+		}
+		return resp, nil
+	}
+
+	outputs := result.outputs()
+	vanity.addOutputs(outputs)
+	outputs["module_path"] = cfg.ModulePath
+	outputs["version"] = version
+	outputs["module_version"] = cfg.ModulePath + "@" + version
+	outputs["proxy_url"] = result.ProxyURL
+	if result.Info != nil {
+		if result.Info.Time != "" {
+			outputs["indexed_at"] = result.Info.Time
+			outputs["indexed_time"] = result.Info.Time
+		}
+		if result.Info.Version != "" {
+			outputs["indexed_version"] = result.Info.Version
+		}
+	}
+	var warnings []string
+
+	// Optionally wait until the proxy actually serves the new version.
+	if cfg.Verify {
+		info, err := p.verifyIndexed(ctx, cfg, result.ProxyURL, version)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("failed to verify indexing: %v", err),
+				Outputs: outputs,
+			}, nil
+		}
+		outputs["indexed_at"] = info.Time
+		outputs["indexed_time"] = info.Time
+	}
+
+	// Querying @latest also makes the proxy refresh its latest pointer. Any
+	// failure here is only a warning: the version itself was notified, and
+	// publishing an older branch legitimately leaves @latest elsewhere.
+	if cfg.Verify || cfg.NotifyLatest {
+		latest, err := p.fetchLatest(ctx, cfg, result.ProxyURL)
+		switch {
+		case err != nil:
+			warnings = append(warnings, fmt.Sprintf("could not query @latest: %v", err))
+		case latest.Version != version && cfg.Verify:
+			outputs["latest_version"] = latest.Version
+			warnings = append(warnings, fmt.Sprintf("proxy @latest reports %s instead of %s", latest.Version, version))
+		default:
+			outputs["latest_version"] = latest.Version
+		}
+		if cfg.Verify {
+			outputs["verified"] = err == nil && latest.Version == version
+		}
+	}
+
+	// Priming the checksum database is best effort; the proxy already has
+	// the version. Verifying it is not: verify_sumdb asked for confirmation.
+	_, noSumDB := matchPrefixPatterns(cfg.NoSumDBPatterns, cfg.ModulePath)
+	switch {
+	case (cfg.NotifySumDB || cfg.VerifySumDB) && noSumDB:
+		outputs["sumdb_skipped"] = true
+	case cfg.VerifySumDB:
+		record, err := p.verifySumDB(ctx, cfg, version)
+		if err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("failed to verify checksum database entry: %v", err),
+				Outputs: outputs,
+			}, nil
+		}
+		outputs["sumdb_notified"] = true
+		outputs["sumdb_record"] = record.HashLine
+		outputs["sumdb_tree_size"] = record.TreeSize
+	case cfg.NotifySumDB:
+		err := p.notifySumDB(ctx, cfg, version)
+		if err != nil {
+			cfg.logf(logLevelWarn, "checksum database lookup failed", map[string]any{
+				"module":  cfg.ModulePath,
+				"version": version,
+				"error":   cfg.redact(err.Error()),
+			})
+			warnings = append(warnings, fmt.Sprintf("could not notify checksum database: %v", err))
+		}
+		outputs["sumdb_notified"] = err == nil
+	}
+
+	// Warming pkg.go.dev only speeds up documentation; it never fails the release.
+	if cfg.WarmPkgsite {
+		err := p.warmPkgsite(ctx, cfg, version)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("could not warm pkg.go.dev: %v", err))
+		}
+		outputs["pkgsite_warmed"] = err == nil
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	// Read response body for error messages.
-	body, err := io.ReadAll(resp.Body)
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: withWarnings(fmt.Sprintf("Go module proxy notified for %s@%s", cfg.ModulePath, version), warnings),
+		Outputs: outputs,
+	}, nil
+}
+
+// withWarnings appends non-fatal warnings to a response message.
+func withWarnings(message string, warnings []string) string {
+	if len(warnings) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (warning: %s)", message, strings.Join(warnings, "; "))
+}
+
+// indexResult describes a successful proxy notification.
+type indexResult struct {
+	proxy.Result
+
+	Cached     bool   // Answered from an earlier notification in the same Execute call
+	RequestURL string // URL that was requested, without credentials
+}
+
+// outputs returns the result's request metrics as response outputs.
+func (r *indexResult) outputs() map[string]any {
+	outputs := map[string]any{
+		"status_code": r.StatusCode,
+		"latency_ms":  r.Latency.Milliseconds(),
+	}
+	if r.Cached {
+		outputs["cached"] = true
+	}
+	if r.RequestURL != "" {
+		outputs["request_url"] = r.RequestURL
+	}
+	return outputs
+}
+
+// triggerProxyIndex sends a request to the Go module proxies to index the version.
+// Proxies are tried in order and the first one to succeed wins; an error, a
+// *proxy.Error, is only returned when every proxy failed. On failure the
+// result, if any, describes the last attempt made.
+func (p *GoModPlugin) triggerProxyIndex(ctx context.Context, cfg *Config, version string) (*indexResult, error) {
+	result, err := p.notifyProxies(ctx, cfg, version)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		var last *proxy.Result
+		if result != nil {
+			last = &result.Result
+		}
+		return result, proxy.NewError(last, err)
 	}
+	return result, nil
+}
 
-	// Handle response status codes.
-	switch resp.StatusCode {
-	case http.StatusOK:
-		// Success - module version is indexed.
-		return nil
-	case http.StatusNotFound:
-		// 404 - module or version not found yet.
-		// This can happen if the tag hasn't propagated to the origin.
-		return fmt.Errorf("module or version not found (404): %s - the tag may need time to propagate", string(body))
-	case http.StatusGone:
-		// 410 - version doesn't exist or has been removed.
-		return fmt.Errorf("version does not exist or is unavailable (410): %s", string(body))
-	default:
-		if resp.StatusCode >= 400 {
-			return fmt.Errorf("proxy returned error status %d: %s", resp.StatusCode, string(body))
+// notifyProxies implements triggerProxyIndex, returning unclassified errors.
+func (p *GoModPlugin) notifyProxies(ctx context.Context, cfg *Config, version string) (*indexResult, error) {
+	proxies := parseProxyList(cfg.ProxyURL, cfg.proxyPolicy())
+	if len(proxies.URLs) == 0 {
+		return nil, fmt.Errorf("no usable proxy URL configured")
+	}
+
+	// Fail fast if the pipeline already gave up on us.
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("proxy notification aborted: %w", err)
+	}
+
+	var result *indexResult
+	var lastErr error
+	failures := make([]string, 0, len(proxies.URLs))
+	for _, proxyURL := range proxies.URLs {
+		result, lastErr = p.indexOnProxy(ctx, cfg, proxyURL, version)
+		if lastErr == nil {
+			// The proxy knows the version; optionally make sure it is the right module.
+			if cfg.CheckMod {
+				if err := p.checkModFile(ctx, cfg, proxyURL, version); err != nil {
+					return result, err
+				}
+			}
+			if cfg.CheckZip {
+				if err := p.checkZip(ctx, cfg, proxyURL, version); err != nil {
+					return result, err
+				}
+			}
+			return result, nil
 		}
-		// Other 2xx/3xx status codes are acceptable.
-		return nil
+		// Do not fall back to the next proxy once the context is done.
+		if ctx.Err() != nil {
+			return result, lastErr
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", proxyURL, lastErr))
+	}
+
+	if len(failures) == 1 {
+		return result, lastErr
+	}
+	return result, fmt.Errorf("all %d proxies failed: %s", len(failures), strings.Join(failures, "; "))
+}
+
+// indexOnProxy asks a single proxy to index the version, retrying transient
+// failures within the configured retry budget. A notification that already
+// succeeded during this Execute call is answered from the request cache. The
+// returned result describes the last attempt and is never nil, even when an
+// error is returned.
+func (p *GoModPlugin) indexOnProxy(ctx context.Context, cfg *Config, proxyURL, version string) (*indexResult, error) {
+	key := proxy.ModuleURL(proxyURL, cfg.ModulePath, proxy.VersionEndpoint(version, ".info"))
+	cached, hit, err := cfg.cache.acquire(ctx, key)
+	if err != nil {
+		return &indexResult{Result: proxy.Result{ProxyURL: proxyURL}}, fmt.Errorf("request aborted: %w", err)
+	}
+	if hit {
+		cfg.logf(logLevelDebug, "proxy notification cached", map[string]any{"url": proxy.RedactURL(key)})
+		cached.Cached = true
+		return cached, nil
+	}
+
+	result, err := p.requestIndex(ctx, cfg, proxyURL, version)
+	cfg.cache.release(key, result, err)
+	return result, err
+}
+
+// requestIndex implements indexOnProxy without the request cache, checking
+// the request URL before handing the notification to the proxy package.
+func (p *GoModPlugin) requestIndex(ctx context.Context, cfg *Config, proxyURL, version string) (*indexResult, error) {
+	result := &indexResult{Result: proxy.Result{ProxyURL: proxyURL}}
+
+	// Build the proxy URL: {proxy_url}/{module}/@v/{version}.info
+	proxyRequestURL := proxy.ModuleURL(proxyURL, cfg.ModulePath, proxy.VersionEndpoint(version, ".info"))
+	result.RequestURL = cfg.redact(stripUserinfo(proxyRequestURL))
+
+	// Validate the final URL.
+	if err := cfg.checkRequestURL(ctx, proxyRequestURL); err != nil {
+		return result, err
+	}
+
+	var bodyPattern *regexp.Regexp
+	if cfg.RetryBodyPattern != "" {
+		var err error
+		if bodyPattern, err = regexp.Compile(cfg.RetryBodyPattern); err != nil {
+			return result, fmt.Errorf("invalid retry body pattern: %w", err)
+		}
+	}
+
+	// Get HTTP client with configured timeout.
+	client, err := cfg.newHTTPClient()
+	if err != nil {
+		return result, err
+	}
+
+	opts := append(cfg.requestOptions(client, proxyRequestURL),
+		proxy.WithRetries(cfg.MaxRetries),
+		proxy.WithBackoff(time.Duration(cfg.RetryBackoffMs)*time.Millisecond),
+		proxy.WithRetryBodyPattern(bodyPattern),
+		proxy.WithLogger(proxyLogger{cfg}),
+		proxy.WithSleep(retrySleep),
+	)
+	notified, err := proxy.Notify(ctx, proxyURL, cfg.ModulePath, version, opts...)
+	result.Result = *notified
+	return result, err
+}
+
+// checkRequestURL applies the SSRF protection to a fully built request URL,
+// including the DNS check unless it is disabled or the host is trusted.
+func (cfg *Config) checkRequestURL(ctx context.Context, requestURL string) error {
+	parsed, err := url.Parse(requestURL)
+	if err != nil {
+		return fmt.Errorf("invalid request URL: %w", err)
+	}
+
+	// Only the scheme and host decide where the request goes; the module
+	// path appended to the proxy URL must not be mistaken for a host.
+	origin := (&url.URL{Scheme: parsed.Scheme, Host: parsed.Host}).String()
+	if err := checkProxyURL(origin, cfg.proxyPolicy()); err != nil {
+		return fmt.Errorf("invalid request URL: %w", err)
+	}
+
+	// The hostname alone says nothing about where it points; check the
+	// resolved addresses unless the host is explicitly trusted. This fails
+	// early and covers dry runs; the dialer checks the address it connects to.
+	if !cfg.SkipDNSCheck && !cfg.proxyPolicy().allowsPrivateHost(parsed.Hostname()) {
+		return checkResolvedHost(ctx, parsed.Hostname(), time.Duration(cfg.Timeout)*time.Second)
 	}
+	return nil
+}
+
+// proxyGet issues a GET request with the plugin's standard headers and
+// returns the response together with its fully read body.
+func proxyGet(ctx context.Context, client HTTPClient, cfg *Config, requestURL string) (*http.Response, []byte, error) {
+	return proxyDo(ctx, client, cfg, http.MethodGet, requestURL)
+}
+
+// proxyDo issues a request with the given method and the plugin's standard
+// headers and returns the response together with its fully read body.
+func proxyDo(ctx context.Context, client HTTPClient, cfg *Config, method, requestURL string) (*http.Response, []byte, error) {
+	return proxy.Do(ctx, method, requestURL, cfg.requestOptions(client, requestURL)...)
+}
+
+// requestOptions returns the proxy request options for a request to
+// requestURL with the configured User-Agent and headers, sending it through
+// client. Credentials are only added when sendsCredentials allows it.
+func (cfg *Config) requestOptions(client HTTPClient, requestURL string) []proxy.Option {
+	opts := []proxy.Option{
+		proxy.WithClient(client),
+		proxy.WithUserAgent(cfg.UserAgent),
+		proxy.WithHeaders(cfg.Headers),
+	}
+	if cfg.sendsCredentials(requestURL) {
+		opts = append(opts,
+			proxy.WithBearerToken(cfg.ProxyToken),
+			proxy.WithBasicAuth(cfg.ProxyUsername, cfg.ProxyPassword),
+		)
+	}
+	return opts
+}
+
+// sendsCredentials reports whether the proxy credentials may be sent with a
+// request to requestURL: only over HTTPS, only to a host named in proxy_url,
+// and never to a well-known public proxy. A token from GO_PROXY_TOKEN meant
+// for a private proxy thus never reaches a fallback such as proxy.golang.org.
+func (cfg *Config) sendsCredentials(requestURL string) bool {
+	parsed, err := url.Parse(requestURL)
+	if err != nil || parsed.Scheme != "https" || publicProxyHost(requestURL) != "" {
+		return false
+	}
+	for _, proxyURL := range parseProxyList(cfg.ProxyURL, cfg.proxyPolicy()).URLs {
+		configured, err := url.Parse(proxyURL)
+		if err == nil && configured.Scheme == "https" && strings.EqualFold(configured.Host, parsed.Host) {
+			return true
+		}
+	}
+	return false
 }
 
 // parseConfig parses the raw configuration into a Config struct.
 func (p *GoModPlugin) parseConfig(raw map[string]any) *Config {
 	parser := helpers.NewConfigParser(raw)
 
-	proxyURL := parser.GetString("proxy_url", "", defaultProxyURL)
+	// Precedence: explicit proxy_url config, then the GOPROXY environment
+	// variable, then the public Go module proxy.
+	proxyURL := getListValue(parser, "proxy_url", "GOPROXY")
 	if proxyURL == "" {
 		proxyURL = defaultProxyURL
 	}
@@ -348,12 +1689,239 @@ func (p *GoModPlugin) parseConfig(raw map[string]any) *Config {
 		timeout = defaultTimeout
 	}
 
+	// "retries" is accepted as an alias; max_retries wins when both are set.
+	maxRetries := parser.GetInt("max_retries", parser.GetInt("retries", defaultMaxRetries))
+	if maxRetries < 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	retryBackoffMs := parser.GetInt("retry_backoff_ms", defaultRetryBackoffMs)
+	if retryBackoffMs < 0 {
+		retryBackoffMs = defaultRetryBackoffMs
+	}
+
+	maxRedirects := parser.GetInt("max_redirects", defaultMaxRedirects)
+	if maxRedirects < 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	// "max_total_duration" is accepted as an alias; total_timeout wins when both are set.
+	totalTimeout := parser.GetInt("total_timeout", parser.GetInt("max_total_duration", 0))
+	if totalTimeout < 0 {
+		totalTimeout = 0
+	}
+
+	maxConcurrency := parser.GetInt("max_concurrency", defaultMaxConcurrency)
+	if maxConcurrency < 1 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+
+	rateLimitPerSec := parser.GetInt("rate_limit_per_sec", 0)
+	if rateLimitPerSec < 0 {
+		rateLimitPerSec = 0
+	}
+
+	maxIdleConns := parser.GetInt("max_idle_conns", defaultMaxIdleConns)
+	if maxIdleConns < 0 {
+		maxIdleConns = defaultMaxIdleConns
+	}
+
+	maxIdleConnsPerHost := parser.GetInt("max_idle_conns_per_host", defaultMaxIdleConnsPerHost)
+	if maxIdleConnsPerHost < 0 {
+		maxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+
+	verifyTimeout := parser.GetInt("verify_timeout", defaultVerifyTimeout)
+	if verifyTimeout <= 0 {
+		verifyTimeout = defaultVerifyTimeout
+	}
+
+	// Never send a header value that could inject further headers.
+	userAgent := strings.TrimSpace(parser.GetString("user_agent", "", ""))
+	if userAgent == "" || strings.ContainsAny(userAgent, "\r\n") {
+		userAgent = defaultUserAgent
+	}
+
+	logLevel := strings.ToLower(parser.GetString("log_level", "", defaultLogLevel))
+	if _, ok := logLevels[logLevel]; !ok {
+		logLevel = defaultLogLevel
+	}
+
+	// Fall back to the module directive in go.mod, or the modules used by
+	// go.work with from_workspace, when no path is configured.
+	goModPath := parser.GetString("go_mod_path", "", defaultGoModPath)
+	fromWorkspace := parser.GetBool("from_workspace", false)
+	modulePaths := splitList(getListValue(parser, "module_path", "GO_MODULE_PATH"))
+	if len(modulePaths) == 0 {
+		if detected, err := detectModulePaths(goModPath, fromWorkspace, nil); err == nil {
+			modulePaths = detected
+		}
+	}
+
+	var modulePath string
+	if len(modulePaths) > 0 {
+		modulePath = modulePaths[0]
+	}
+
+	_, privateSet := raw["private"]
+
 	return &Config{
-		ModulePath: parser.GetString("module_path", "GO_MODULE_PATH", ""),
-		ProxyURL:   proxyURL,
-		Private:    parser.GetBool("private", false),
-		Timeout:    timeout,
+		ModulePath:       modulePath,
+		ModulePaths:      modulePaths,
+		Versions:         splitList(getListValue(parser, "versions", "")),
+		GoModPath:        goModPath,
+		FromWorkspace:    fromWorkspace,
+		VersionFile:      strings.TrimSpace(parser.GetString("version_file", "", "")),
+		StrictHost:       parser.GetBool("strict_host", false),
+		MaxConcurrency:   maxConcurrency,
+		RateLimitPerSec:  rateLimitPerSec,
+		ProxyURL:         proxyURL,
+		Private:          parser.GetBool("private", false),
+		PrivateSet:       privateSet,
+		PrivatePatterns:  getListValue(parser, "private_patterns", "GOPRIVATE"),
+		Timeout:          timeout,
+		MaxRetries:       maxRetries,
+		RetryBackoffMs:   retryBackoffMs,
+		RetryBodyPattern: parser.GetString("retry_body_pattern", "", ""),
+		Verify:           parser.GetBool("verify", false),
+		VerifyTimeout:    verifyTimeout,
+		TotalTimeout:     totalTimeout,
+		NotifyLatest:     parser.GetBool("notify_latest", false),
+		LogLevel:         logLevel,
+
+		ProxyToken:        getProxyToken(parser),
+		AllowPrivateProxy: parser.GetBool("allow_private_proxy", false),
+		ProxyUsername:     parser.GetString("proxy_username", "", ""),
+		ProxyPassword:     parser.GetString("proxy_password", "", ""),
+
+		AllowedHosts: splitList(getListValue(parser, "allowed_hosts", "")),
+
+		InsecurePatterns: getListValue(parser, "insecure_patterns", "GOINSECURE"),
+
+		UserAgent: userAgent,
+		Headers:   parseHeaders(parser.GetMap("headers")),
+
+		// resolve_and_check is the positive form of skip_dns_check; either
+		// one disables the check.
+		SkipDNSCheck: parser.GetBool("skip_dns_check", false) || !parser.GetBool("resolve_and_check", true),
+
+		// "verify_gomod" is accepted as an alias for check_mod.
+		CheckMod:      parser.GetBool("check_mod", false) || parser.GetBool("verify_gomod", false),
+		CheckZip:      parser.GetBool("check_zip", false),
+		SoftNotFound:  parser.GetBool("soft_not_found", false),
+		DisableCache:  parser.GetBool("disable_cache", false),
+		FailIfExists:  parser.GetBool("fail_if_exists", false),
+		CheckExisting: parser.GetBool("check_existing", false),
+		AutoVersion:   parser.GetBool("auto_version", false),
+		AllowPseudo:   parser.GetBool("allow_pseudo", true),
+		CheckVanity:   parser.GetBool("check_vanity", false),
+
+		DryRunVerify:       parser.GetBool("dry_run_verify", false),
+		DryRunConnectivity: parser.GetBool("dry_run_connectivity", false),
+
+		NotifySumDB: parser.GetBool("notify_sumdb", false),
+		VerifySumDB: parser.GetBool("verify_sumdb", false),
+		SumDBURL:    parser.GetString("sumdb_url", "", defaultSumDBURL),
+
+		NoSumDBPatterns: getListValue(parser, "no_sumdb_patterns", "GONOSUMDB"),
+
+		WarmPkgsite: parser.GetBool("warm_pkgsite", false),
+
+		TLSMinVersion: tlsVersions[getTLSMinVersion(raw)],
+		MaxRedirects:  maxRedirects,
+		CACertFile:    parser.GetString("ca_cert_file", "", ""),
+
+		RestrictRedirectHost: parser.GetBool("restrict_redirect_host", false),
+		DisableHTTP2:         parser.GetBool("disable_http2", false),
+
+		ClientCertFile: parser.GetString("client_cert_file", "", ""),
+		ClientKeyFile:  parser.GetString("client_key_file", "", ""),
+
+		InsecureSkipVerify: parser.GetBool("insecure_skip_verify", false),
+
+		MaxIdleConns:        maxIdleConns,
+		MaxIdleConnsPerHost: maxIdleConnsPerHost,
+
+		HTTPSProxy: strings.TrimSpace(parser.GetString("https_proxy", "", "")),
+		NoProxy:    strings.TrimSpace(parser.GetString("no_proxy", "", "")),
+	}
+}
+
+// getListValue returns a list option as a comma-separated string. The option
+// may be configured either as a string or as an array of strings, with an
+// optional environment variable fallback.
+func getListValue(parser *helpers.ConfigParser, key, envKey string) string {
+	if values := parser.GetStringSlice(key, nil); len(values) > 0 {
+		return strings.Join(values, ",")
 	}
+	return parser.GetString(key, envKey, "")
+}
+
+// getTLSMinVersion returns the configured tls_min_version as a string,
+// accepting numeric values such as 1.2 from YAML. Unknown values fall back
+// to the default.
+func getTLSMinVersion(raw map[string]any) string {
+	var version string
+	switch v := raw["tls_min_version"].(type) {
+	case string:
+		version = strings.TrimSpace(v)
+	case float64:
+		version = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	if _, ok := tlsVersions[version]; !ok {
+		return defaultTLSMinVersion
+	}
+	return version
+}
+
+// getProxyToken returns the configured proxy token, falling back to the
+// GO_PROXY_TOKEN and then GOPROXY_TOKEN environment variables.
+func getProxyToken(parser *helpers.ConfigParser) string {
+	return parser.GetString("proxy_token", "GO_PROXY_TOKEN", os.Getenv("GOPROXY_TOKEN"))
+}
+
+// parseHeaders converts the headers config map into header values, dropping
+// entries Validate would reject.
+func parseHeaders(raw map[string]any) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if checkHeader(key, value) != nil {
+			continue
+		}
+		headers[key] = value.(string)
+	}
+	return headers
+}
+
+// checkHeader rejects a custom header that is not a string, overrides Host
+// or could inject further headers.
+func checkHeader(key string, value any) error {
+	s, ok := value.(string)
+	switch {
+	case !ok:
+		return fmt.Errorf("header %q must be a string", key)
+	case key == "" || strings.ContainsAny(key, "\r\n:"):
+		return fmt.Errorf("header name %q is invalid", key)
+	case strings.ContainsAny(s, "\r\n"):
+		return fmt.Errorf("header %q must not contain CR or LF characters", key)
+	case strings.EqualFold(key, "Host"):
+		return fmt.Errorf("the Host header cannot be overridden")
+	}
+	return nil
+}
+
+// splitList splits a comma-separated value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 // Validate validates the plugin configuration.
@@ -361,39 +1929,215 @@ func (p *GoModPlugin) Validate(_ context.Context, config map[string]any) (*plugi
 	vb := helpers.NewValidationBuilder()
 	parser := helpers.NewConfigParser(config)
 
-	// Validate module path.
-	modulePath := parser.GetString("module_path", "GO_MODULE_PATH", "")
-	if modulePath == "" {
-		vb.AddError("module_path", "Go module path is required")
-	} else if err := validateModulePath(modulePath); err != nil {
-		vb.AddError("module_path", err.Error())
+	// Validate module paths, falling back to the ones declared in go.work
+	// (with from_workspace) or go.mod.
+	modulePaths := splitList(getListValue(parser, "module_path", "GO_MODULE_PATH"))
+	if len(modulePaths) == 0 {
+		goModPath := parser.GetString("go_mod_path", "", defaultGoModPath)
+		detected, err := detectModulePaths(goModPath, parser.GetBool("from_workspace", false), nil)
+		if err != nil {
+			vb.AddError("module_path", fmt.Sprintf("Go module path is required (could not detect it from %s: %v)", goModPath, err))
+		} else {
+			modulePaths = detected
+		}
+	}
+	strictHost := parser.GetBool("strict_host", false)
+	for _, modulePath := range modulePaths {
+		err := validateModulePath(modulePath)
+		if err == nil && strictHost {
+			err = checkStrictHost(modulePath)
+		}
+		if err != nil {
+			vb.AddError("module_path", err.Error())
+		}
 	}
 
-	// Validate proxy URL if provided.
-	proxyURL := parser.GetString("proxy_url", "", "")
-	if proxyURL != "" {
-		if err := validateProxyURL(proxyURL); err != nil {
-			vb.AddError("proxy_url", err.Error())
+	for _, version := range splitList(getListValue(parser, "versions", "")) {
+		if err := validateVersion(proxy.NormalizeVersion(version)); err != nil {
+			vb.AddError("versions", err.Error())
 		}
 	}
 
-	// Validate timeout if provided.
-	if rawTimeout, ok := config["timeout"]; ok {
-		switch t := rawTimeout.(type) {
-		case int:
-			if t <= 0 {
-				vb.AddError("timeout", "timeout must be a positive integer")
+	// Validate each proxy URL entry if provided. Plain HTTP is only
+	// accepted when every module is covered by insecure_patterns.
+	proxyURL := getListValue(parser, "proxy_url", "")
+	if proxyURL != "" {
+		insecurePatterns := getListValue(parser, "insecure_patterns", "GOINSECURE")
+		policy := proxyPolicy{
+			AllowPrivate: parser.GetBool("allow_private_proxy", false),
+			AllowedHosts: splitList(getListValue(parser, "allowed_hosts", "")),
+			AllowHTTP:    insecurePatterns != "" && len(modulePaths) > 0,
+		}
+		for _, modulePath := range modulePaths {
+			if _, insecure := matchPrefixPatterns(insecurePatterns, modulePath); !insecure {
+				policy.AllowHTTP = false
 			}
-		case float64:
-			if t <= 0 {
-				vb.AddError("timeout", "timeout must be a positive integer")
+		}
+		for _, invalid := range parseProxyList(proxyURL, policy).Invalid {
+			vb.AddError("proxy_url", invalid)
+		}
+	}
+
+	// Validate numeric options if provided.
+	validateIntOption(vb, config, "timeout", 1)
+	validateIntOption(vb, config, "max_retries", 0)
+	validateIntOption(vb, config, "retries", 0)
+	validateIntOption(vb, config, "retry_backoff_ms", 0)
+	validateIntOption(vb, config, "verify_timeout", 1)
+	validateIntOption(vb, config, "max_redirects", 0)
+	validateIntOption(vb, config, "total_timeout", 0)
+	validateIntOption(vb, config, "max_total_duration", 0)
+	validateIntOption(vb, config, "max_concurrency", 1)
+	validateIntOption(vb, config, "rate_limit_per_sec", 0)
+	validateIntOption(vb, config, "max_idle_conns", 0)
+	validateIntOption(vb, config, "max_idle_conns_per_host", 0)
+
+	// A total deadline shorter than one request would cut off the first attempt.
+	for _, key := range []string{"total_timeout", "max_total_duration"} {
+		if totalTimeout := parser.GetInt(key, 0); totalTimeout > 0 {
+			if timeout := parser.GetInt("timeout", defaultTimeout); totalTimeout < timeout {
+				vb.AddError(key, fmt.Sprintf("%s (%ds) must not be smaller than timeout (%ds)", key, totalTimeout, timeout))
 			}
-		case string:
-			// Allow string conversion but warn about type.
-		default:
-			vb.AddError("timeout", "timeout must be an integer")
+		}
+	}
+
+	// Validate retry body pattern if provided.
+	if pattern := parser.GetString("retry_body_pattern", "", ""); pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			vb.AddError("retry_body_pattern", fmt.Sprintf("invalid regular expression: %v", err))
+		}
+	}
+
+	// A password is useless without the user it belongs to.
+	if parser.GetString("proxy_password", "", "") != "" && parser.GetString("proxy_username", "", "") == "" {
+		vb.AddError("proxy_password", "proxy_password requires proxy_username")
+	}
+
+	// Reject header injection through the User-Agent.
+	if strings.ContainsAny(parser.GetString("user_agent", "", ""), "\r\n") {
+		vb.AddError("user_agent", "user_agent must not contain CR or LF characters")
+	}
+
+	headers := parser.GetMap("headers")
+	headerKeys := make([]string, 0, len(headers))
+	for key := range headers {
+		headerKeys = append(headerKeys, key)
+	}
+	sort.Strings(headerKeys)
+	for _, key := range headerKeys {
+		if err := checkHeader(key, headers[key]); err != nil {
+			vb.AddError("headers", err.Error())
+		}
+	}
+
+	// The egress proxy is operator-chosen infrastructure, so only its form
+	// is checked; the SSRF protection applies to the request targets.
+	if httpsProxy := strings.TrimSpace(parser.GetString("https_proxy", "", "")); httpsProxy != "" {
+		if parsed, err := url.Parse(httpsProxy); err != nil || parsed.Host == "" {
+			vb.AddError("https_proxy", "https_proxy must be a URL like http://proxy.example.com:3128")
+		} else if !slices.Contains([]string{"http", "https", "socks5"}, parsed.Scheme) {
+			vb.AddError("https_proxy", "https_proxy must use the http, https or socks5 scheme")
+		}
+	}
+
+	// Validate TLS minimum version if provided.
+	if raw, ok := config["tls_min_version"]; ok {
+		version := fmt.Sprint(raw)
+		if _, supported := tlsVersions[version]; !supported {
+			vb.AddError("tls_min_version", `tls_min_version must be "1.2" or "1.3"`)
+		}
+	}
+
+	// Never disable certificate checks against a public proxy.
+	if parser.GetBool("insecure_skip_verify", false) {
+		proxyList := getListValue(parser, "proxy_url", "")
+		if proxyList == "" {
+			proxyList = defaultProxyURL
+		}
+		if host := publicProxyHost(proxyList); host != "" {
+			vb.AddError("insecure_skip_verify", fmt.Sprintf("insecure_skip_verify cannot be used with the public proxy %s", host))
+		}
+	}
+
+	// The checksum database URL gets the same SSRF checks as proxy URLs.
+	if sumDBURL := parser.GetString("sumdb_url", "", ""); sumDBURL != "" {
+		policy := proxyPolicy{
+			AllowPrivate: parser.GetBool("allow_private_proxy", false),
+			AllowedHosts: splitList(getListValue(parser, "allowed_hosts", "")),
+		}
+		if err := checkProxyURL(sumDBURL, policy); err != nil {
+			vb.AddError("sumdb_url", err.Error())
+		}
+	}
+
+	// Validate private module patterns if provided.
+	if err := checkPatterns(getListValue(parser, "private_patterns", "GOPRIVATE")); err != nil {
+		vb.AddError("private_patterns", err.Error())
+	}
+	if err := checkPatterns(getListValue(parser, "no_sumdb_patterns", "GONOSUMDB")); err != nil {
+		vb.AddError("no_sumdb_patterns", err.Error())
+	}
+	if err := checkPatterns(getListValue(parser, "insecure_patterns", "GOINSECURE")); err != nil {
+		vb.AddError("insecure_patterns", err.Error())
+	}
+
+	// Validate the CA certificate file if provided.
+	if caCertFile := parser.GetString("ca_cert_file", "", ""); caCertFile != "" {
+		if _, err := loadCertPool(caCertFile); err != nil {
+			vb.AddError("ca_cert_file", err.Error())
+		}
+	}
+
+	// Validate the mutual TLS keypair; both halves are required together.
+	clientCertFile := parser.GetString("client_cert_file", "", "")
+	clientKeyFile := parser.GetString("client_key_file", "", "")
+	switch {
+	case clientCertFile != "" && clientKeyFile == "":
+		vb.AddError("client_key_file", "client_key_file is required when client_cert_file is set")
+	case clientKeyFile != "" && clientCertFile == "":
+		vb.AddError("client_cert_file", "client_cert_file is required when client_key_file is set")
+	case clientCertFile != "":
+		if _, err := loadClientCertificate(clientCertFile, clientKeyFile); err != nil {
+			vb.AddError("client_cert_file", err.Error())
+		}
+	}
+
+	// Validate log level if provided.
+	if level := parser.GetString("log_level", "", ""); level != "" {
+		if _, ok := logLevels[strings.ToLower(level)]; !ok {
+			vb.AddError("log_level", "log_level must be one of: debug, info, warn, error")
 		}
 	}
 
 	return vb.Build(), nil
 }
+
+// validateIntOption checks that an optional integer option is at least minValue.
+func validateIntOption(vb *helpers.ValidationBuilder, config map[string]any, key string, minValue int) {
+	raw, ok := config[key]
+	if !ok {
+		return
+	}
+
+	var value int
+	switch v := raw.(type) {
+	case int:
+		value = v
+	case float64:
+		value = int(v)
+	case string:
+		// Allow string conversion but warn about type.
+		return
+	default:
+		vb.AddError(key, fmt.Sprintf("%s must be an integer", key))
+		return
+	}
+
+	if value < minValue {
+		if minValue == 1 {
+			vb.AddError(key, fmt.Sprintf("%s must be a positive integer", key))
+		} else {
+			vb.AddError(key, fmt.Sprintf("%s must be a non-negative integer", key))
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// prePublish validates the module paths, version and proxy URLs and checks
+// that the proxy is reachable before the release is published, so mistakes
+// and outages fail the release early instead of after tagging. Validation
+// never contacts the proxy. Private modules and disabled proxies skip the
+// proxy checks.
+func (p *GoModPlugin) prePublish(ctx context.Context, cfg *Config, releaseCtx plugin.ReleaseContext) (*plugin.ExecuteResponse, error) {
+	modulePaths := cfg.ModulePaths
+	if len(modulePaths) == 0 {
+		modulePaths = []string{cfg.ModulePath}
+	}
+	for _, modulePath := range modulePaths {
+		if err := cfg.checkModulePath(modulePath); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   fmt.Sprintf("invalid module path: %v", err),
+			}, nil
+		}
+		// With auto_version the version is only detected after publishing.
+		if cfg.AutoVersion && releaseCtx.Version == "" && releaseCtx.TagName == "" {
+			continue
+		}
+		if _, err := releaseVersion(modulePath, releaseCtx); err != nil {
+			return &plugin.ExecuteResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, nil
+		}
+	}
+
+	// Every module that will be published is checked, each with its own
+	// private and insecure pattern matches.
+	var responses []*plugin.ExecuteResponse
+	var checked []string
+	for _, modulePath := range modulePaths {
+		moduleCfg := *cfg
+		moduleCfg.ModulePath = modulePath
+		moduleCfg.ModulePaths = nil
+		if moduleCfg.isPrivate() {
+			continue
+		}
+
+		resp := p.checkProxies(ctx, &moduleCfg)
+		if !resp.Success && len(modulePaths) > 1 {
+			resp.Error = fmt.Sprintf("%s: %s", modulePath, resp.Error)
+		}
+		if !resp.Success {
+			return resp, nil
+		}
+		responses = append(responses, resp)
+		checked = append(checked, modulePath)
+	}
+
+	switch len(responses) {
+	case 0:
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "Skipping proxy reachability check for private module",
+		}, nil
+	case 1:
+		return responses[0], nil
+	}
+
+	messages := make([]string, len(responses))
+	for i, resp := range responses {
+		messages[i] = fmt.Sprintf("%s: %s", checked[i], resp.Message)
+	}
+	return &plugin.ExecuteResponse{
+		Success: true,
+		Message: strings.Join(messages, "; "),
+		Outputs: responses[0].Outputs,
+	}, nil
+}
+
+// checkProxies validates the proxy list for cfg.ModulePath and checks that
+// at least one proxy is reachable for it.
+func (p *GoModPlugin) checkProxies(ctx context.Context, cfg *Config) *plugin.ExecuteResponse {
+	proxies := parseProxyList(cfg.ProxyURL, cfg.proxyPolicy())
+	if len(proxies.Invalid) > 0 {
+		return &plugin.ExecuteResponse{
+			Success: false,
+			Error:   fmt.Sprintf("invalid proxy URL: %s", strings.Join(proxies.Invalid, "; ")),
+		}
+	}
+	if len(proxies.URLs) == 0 {
+		return &plugin.ExecuteResponse{
+			Success: true,
+			Message: "No proxy configured, skipping reachability check",
+		}
+	}
+
+	// Any reachable proxy is enough since notification falls back through the list.
+	failures := make([]string, 0, len(proxies.URLs))
+	for _, proxyURL := range proxies.URLs {
+		err := p.checkReachable(ctx, cfg, proxyURL)
+		if err == nil {
+			return &plugin.ExecuteResponse{
+				Success: true,
+				Message: fmt.Sprintf("Go module proxy %s is reachable", proxyURL),
+				Outputs: map[string]any{"proxy_url": proxyURL},
+			}
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", proxyURL, err))
+	}
+
+	return &plugin.ExecuteResponse{
+		Success: false,
+		Error:   fmt.Sprintf("proxy is unreachable: %s", strings.Join(failures, "; ")),
+	}
+}
+
+// checkReachable issues a single lightweight request for the module's version
+// list. Only transport errors and server errors count as unreachable; a 404
+// simply means the module has not been published yet.
+func (p *GoModPlugin) checkReachable(ctx context.Context, cfg *Config, proxyURL string) error {
+	requestURL := proxy.ModuleURL(proxyURL, cfg.ModulePath, "@v/list")
+	if err := cfg.checkRequestURL(ctx, requestURL); err != nil {
+		return err
+	}
+
+	client, err := cfg.newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	resp, _, err := proxyGet(ctx, client, cfg, requestURL)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("proxy returned error status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+// Package main provides tests for the pre-publish reachability check.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExecutePrePublishReachability(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		config          map[string]any
+		mockFunc        func(req *http.Request) (*http.Response, error)
+		expectedSuccess bool
+		expectedCalls   int
+		errContains     string
+	}{
+		{
+			name: "reachable",
+			mockFunc: func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusOK, "v1.0.0\nv1.1.0\n"), nil
+			},
+			expectedSuccess: true,
+			expectedCalls:   1,
+		},
+		{
+			name: "module not published yet",
+			mockFunc: func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusNotFound, "not found"), nil
+			},
+			expectedSuccess: true,
+			expectedCalls:   1,
+		},
+		{
+			name: "unreachable",
+			mockFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("dial tcp: connection refused")
+			},
+			expectedCalls: 1,
+			errContains:   "connection refused",
+		},
+		{
+			name: "server error",
+			mockFunc: func(req *http.Request) (*http.Response, error) {
+				return mockResponse(http.StatusBadGateway, "bad gateway"), nil
+			},
+			expectedCalls: 1,
+			errContains:   "proxy returned error status 502",
+		},
+		{
+			name:   "fallback proxy reachable",
+			config: map[string]any{"proxy_url": "https://goproxy.io,https://proxy.golang.org"},
+			mockFunc: func(req *http.Request) (*http.Response, error) {
+				if req.URL.Host == "goproxy.io" {
+					return mockResponse(http.StatusServiceUnavailable, "down"), nil
+				}
+				return mockResponse(http.StatusOK, ""), nil
+			},
+			expectedSuccess: true,
+			expectedCalls:   2,
+		},
+		{
+			name:   "private module is a no-op",
+			config: map[string]any{"private": true},
+			mockFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("unexpected request")
+			},
+			expectedSuccess: true,
+		},
+		{
+			name:   "proxy off is a no-op",
+			config: map[string]any{"proxy_url": "off"},
+			mockFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, errors.New("unexpected request")
+			},
+			expectedSuccess: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					return tt.mockFunc(req)
+				},
+			}
+
+			config := map[string]any{"module_path": "github.com/example/module"}
+			for key, value := range tt.config {
+				config[key] = value
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook:    plugin.HookPrePublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+			if len(paths) != tt.expectedCalls {
+				t.Fatalf("expected %d requests, got %v", tt.expectedCalls, paths)
+			}
+			for _, path := range paths {
+				if path != "/github.com/example/module/@v/list" {
+					t.Errorf("expected @v/list request, got %s", path)
+				}
+			}
+		})
+	}
+}
+
+func TestExecutePrePublishValidation(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		releaseCtx  plugin.ReleaseContext
+		errContains string
+	}{
+		{
+			name:        "invalid module path",
+			config:      map[string]any{"module_path": "not a module"},
+			releaseCtx:  plugin.ReleaseContext{Version: "1.0.0"},
+			errContains: "invalid module path",
+		},
+		{
+			name:        "invalid module path in list",
+			config:      map[string]any{"module_path": []any{"github.com/example/module", "bad path"}},
+			releaseCtx:  plugin.ReleaseContext{Version: "1.0.0"},
+			errContains: "invalid module path",
+		},
+		{
+			name:        "missing version",
+			config:      map[string]any{"module_path": "github.com/example/module"},
+			errContains: "version is required",
+		},
+		{
+			name:        "malformed version",
+			config:      map[string]any{"module_path": "github.com/example/module"},
+			releaseCtx:  plugin.ReleaseContext{Version: "1.0"},
+			errContains: "invalid module version",
+		},
+		{
+			name:        "major version suffix mismatch",
+			config:      map[string]any{"module_path": "github.com/example/module/v2"},
+			releaseCtx:  plugin.ReleaseContext{Version: "3.0.0"},
+			errContains: "can only publish v2.x.x versions",
+		},
+		{
+			name:        "invalid proxy URL",
+			config:      map[string]any{"module_path": "github.com/example/module", "proxy_url": "http://proxy.example.com"},
+			releaseCtx:  plugin.ReleaseContext{Version: "1.0.0"},
+			errContains: "must use HTTPS",
+		},
+		{
+			name:        "auto_version defers the missing version",
+			config:      map[string]any{"module_path": "github.com/example/module", "auto_version": true, "proxy_url": "http://proxy.example.com"},
+			errContains: "must use HTTPS",
+		},
+		{
+			name:        "private module still validates the version",
+			config:      map[string]any{"module_path": "github.com/example/module", "private": true},
+			releaseCtx:  plugin.ReleaseContext{Version: "not-a-version"},
+			errContains: "invalid module version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					t.Errorf("unexpected request to %s", req.URL)
+					return mockResponse(http.StatusOK, ""), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPrePublish,
+				Config:  tt.config,
+				Context: tt.releaseCtx,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success {
+				t.Fatal("expected failure")
+			}
+			if !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+		})
+	}
+}
+
+func TestExecutePrePublishPrivateSkipsProxyValidation(t *testing.T) {
+	p := &GoModPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPrePublish,
+		Config: map[string]any{
+			"module_path": "github.com/example/module",
+			"private":     true,
+			"proxy_url":   "http://proxy.example.com",
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Errorf("expected success for a private module, got error: %s", resp.Error)
+	}
+}
+
+func TestExecutePrePublishChecksEveryModule(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		config          map[string]any
+		down            string
+		expectedSuccess bool
+		expectedPaths   []string
+		errContains     string
+	}{
+		{
+			name:            "every module reachable",
+			config:          map[string]any{"module_path": "github.com/org/mono/a,github.com/org/mono/b"},
+			expectedSuccess: true,
+			expectedPaths:   []string{"/github.com/org/mono/a/@v/list", "/github.com/org/mono/b/@v/list"},
+		},
+		{
+			name:            "second module unreachable",
+			config:          map[string]any{"module_path": "github.com/org/mono/a,github.com/org/mono/b"},
+			down:            "/github.com/org/mono/b/@v/list",
+			expectedSuccess: false,
+			expectedPaths:   []string{"/github.com/org/mono/a/@v/list", "/github.com/org/mono/b/@v/list"},
+			errContains:     "github.com/org/mono/b: proxy is unreachable",
+		},
+		{
+			name: "private second module is skipped",
+			config: map[string]any{
+				"module_path":      "github.com/org/mono/a,github.com/acme/secret",
+				"private_patterns": "github.com/acme/*",
+			},
+			down:            "/github.com/acme/secret/@v/list",
+			expectedSuccess: true,
+			expectedPaths:   []string{"/github.com/org/mono/a/@v/list"},
+		},
+		{
+			name: "private first module does not skip the rest",
+			config: map[string]any{
+				"module_path":      "github.com/acme/secret,github.com/org/mono/b",
+				"private_patterns": "github.com/acme/*",
+			},
+			down:            "/github.com/org/mono/b/@v/list",
+			expectedSuccess: false,
+			expectedPaths:   []string{"/github.com/org/mono/b/@v/list"},
+			errContains:     "github.com/org/mono/b: proxy is unreachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					if req.URL.Path == tt.down {
+						return mockResponse(http.StatusServiceUnavailable, "down"), nil
+					}
+					return mockResponse(http.StatusNotFound, "not found"), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPrePublish,
+				Config:  tt.config,
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(tt.expectedPaths) {
+				t.Errorf("expected requests %v, got %v", tt.expectedPaths, paths)
+			}
+		})
+	}
+}
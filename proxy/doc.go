@@ -0,0 +1,3 @@
+// Package proxy holds the Go module proxy notification used by the GoMod
+// plugin, so that other Relicta plugins can import it.
+package proxy
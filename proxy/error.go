@@ -0,0 +1,84 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Error is returned when a proxy notification failed. Kind is the stable
+// failure class, so callers can branch on it instead of matching the message.
+type Error struct {
+	StatusCode int    // Status code of the last attempt, or 0 if no response was received
+	Kind       string // Failure class, e.g. "not_found", "auth" or "network"
+	Retryable  bool   // Running the release step again may succeed
+	Err        error  // Underlying error, which carries the human-readable message
+}
+
+// Error returns the underlying error's message.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewError classifies a failed notification as an *Error. result describes
+// the last attempt made and is nil when no request was made.
+func NewError(result *Result, err error) *Error {
+	kind, retryable := failureCode(result, err)
+	proxyErr := &Error{Kind: kind, Retryable: retryable, Err: err}
+	if result != nil {
+		proxyErr.StatusCode = result.StatusCode
+	}
+	return proxyErr
+}
+
+// failureCode classifies a failed notification into a machine-readable error
+// code and reports whether running the release step again may succeed:
+//
+//	not_found     404, the tag may not have propagated yet (retryable)
+//	gone          410, the version was removed or never existed
+//	rate_limited  429 (retryable)
+//	server_error  5xx (retryable)
+//	auth          401 or 403, the proxy rejected the credentials
+//	client_error  any other 4xx
+//	not_ready     the retry body pattern matched a success body (retryable)
+//	network       no response was received (retryable)
+//	timeout       the deadline expired (retryable)
+//	canceled      the pipeline canceled the run
+//	invalid       the request was refused before it was sent, or the
+//	              proxy served a response that failed a check
+func failureCode(result *Result, err error) (string, bool) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout", true
+	case errors.Is(err, context.Canceled):
+		return "canceled", false
+	case result == nil || result.Attempts == 0:
+		return "invalid", false
+	}
+
+	switch status := result.StatusCode; {
+	case status == 0:
+		return "network", true
+	case status == http.StatusNotFound:
+		return "not_found", true
+	case status == http.StatusGone:
+		return "gone", false
+	case status == http.StatusTooManyRequests:
+		return "rate_limited", true
+	case status >= 500:
+		return "server_error", true
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return "auth", false
+	case status >= 400:
+		return "client_error", false
+	case result.Retry:
+		return "not_ready", true
+	default:
+		return "invalid", false
+	}
+}
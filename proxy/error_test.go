@@ -0,0 +1,63 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestFailureCode(t *testing.T) {
+	tests := []struct {
+		name              string
+		result            *Result
+		err               error
+		expectedCode      string
+		expectedRetryable bool
+	}{
+		{
+			name:              "deadline expired",
+			result:            &Result{Attempts: 2, StatusCode: http.StatusServiceUnavailable},
+			err:               fmt.Errorf("retry aborted: %w", context.DeadlineExceeded),
+			expectedCode:      "timeout",
+			expectedRetryable: true,
+		},
+		{
+			name:         "canceled",
+			err:          fmt.Errorf("proxy notification aborted: %w", context.Canceled),
+			expectedCode: "canceled",
+		},
+		{
+			name:         "refused before sending",
+			result:       &Result{},
+			err:          errors.New("invalid request URL: proxy URL cannot point to private network"),
+			expectedCode: "invalid",
+		},
+		{
+			name:              "body pattern matched",
+			result:            &Result{Attempts: 4, StatusCode: http.StatusOK, Retry: true},
+			err:               errors.New("proxy reported the version is not ready (status 200)"),
+			expectedCode:      "not_ready",
+			expectedRetryable: true,
+		},
+		{
+			name:         "go.mod check failed",
+			result:       &Result{Attempts: 1, StatusCode: http.StatusOK},
+			err:          errors.New("go.mod declares module github.com/other/repo"),
+			expectedCode: "invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, retryable := failureCode(tt.result, tt.err)
+			if code != tt.expectedCode {
+				t.Errorf("expected code %q, got %q", tt.expectedCode, code)
+			}
+			if retryable != tt.expectedRetryable {
+				t.Errorf("expected retryable=%v, got %v", tt.expectedRetryable, retryable)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package proxy
+
+import (
+	"time"
+)
+
+// MetricsRecorder receives counts and latencies of proxy notifications.
+// Several modules may be notified in parallel, so implementations must be
+// safe for concurrent use.
+type MetricsRecorder interface {
+	// ObserveRequest records one request to proxy. status is 0 when no
+	// response was received.
+	ObserveRequest(proxy string, status int, dur time.Duration)
+	// IncRetry counts a retry of a request to proxy.
+	IncRetry(proxy string)
+}
+
+// noopMetrics discards all observations.
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveRequest(string, int, time.Duration) {}
+func (noopMetrics) IncRetry(string)                           {}
+
+// metrics is the recorder used for proxy requests.
+var metrics MetricsRecorder = noopMetrics{}
+
+// SetMetricsRecorder installs r to receive proxy request metrics. A nil
+// recorder restores the default no-op recorder.
+func SetMetricsRecorder(r MetricsRecorder) {
+	if r == nil {
+		r = noopMetrics{}
+	}
+	metrics = r
+}
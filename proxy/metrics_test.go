@@ -0,0 +1,31 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+)
+
+// countingRecorder counts the observations it receives.
+type countingRecorder struct {
+	requests int
+	retries  int
+}
+
+func (r *countingRecorder) ObserveRequest(string, int, time.Duration) { r.requests++ }
+func (r *countingRecorder) IncRetry(string)                           { r.retries++ }
+
+func TestSetMetricsRecorder(t *testing.T) {
+	original := metrics
+	defer func() { metrics = original }()
+
+	recorder := &countingRecorder{}
+	SetMetricsRecorder(recorder)
+	if metrics != recorder {
+		t.Fatalf("expected the installed recorder, got %T", metrics)
+	}
+
+	SetMetricsRecorder(nil)
+	if _, ok := metrics.(noopMetrics); !ok {
+		t.Errorf("expected no-op recorder, got %T", metrics)
+	}
+}
@@ -0,0 +1,475 @@
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+)
+
+// DefaultURL is the public Go module proxy, used when no proxy URL is given.
+const DefaultURL = "https://proxy.golang.org"
+
+// DefaultUserAgent is the User-Agent sent when none is configured.
+const DefaultUserAgent = "relicta-gomod-plugin/2.0.0"
+
+// Defaults for the request options.
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultRetries      = 3
+	defaultBackoff      = time.Second
+	defaultMaxRedirects = 3
+)
+
+// Log levels passed to a Logger, from most to least verbose.
+const (
+	levelDebug = "debug"
+	levelInfo  = "info"
+	levelWarn  = "warn"
+	levelError = "error"
+)
+
+// HTTPClient sends proxy requests. *http.Client satisfies it.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Logger receives structured entries about proxy requests. level is one of
+// "debug", "info", "warn" or "error"; filtering is up to the implementation.
+type Logger interface {
+	Log(level, msg string, fields map[string]any)
+}
+
+// noopLogger discards all entries.
+type noopLogger struct{}
+
+func (noopLogger) Log(string, string, map[string]any) {}
+
+// options holds the settings of a proxy request.
+type options struct {
+	client      HTTPClient
+	timeout     time.Duration
+	headers     map[string]string
+	userAgent   string
+	token       string
+	username    string
+	password    string
+	retries     int
+	backoff     time.Duration
+	bodyPattern *regexp.Regexp
+	logger      Logger
+	sleep       func(context.Context, time.Duration) error
+}
+
+// Option customizes a proxy request.
+type Option func(*options)
+
+// newOptions applies opts to the defaults.
+func newOptions(opts []Option) *options {
+	o := &options{
+		timeout:   defaultTimeout,
+		userAgent: DefaultUserAgent,
+		retries:   defaultRetries,
+		backoff:   defaultBackoff,
+		logger:    noopLogger{},
+		sleep:     SleepContext,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.client == nil {
+		o.client = newClient(o.timeout)
+	}
+	return o
+}
+
+// WithTimeout sets the per-attempt request timeout. Non-positive values keep
+// the default of 30 seconds. It has no effect together with WithClient.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithHeaders adds extra headers to every proxy request. Headers that could
+// override Host or inject further headers are dropped.
+func WithHeaders(headers map[string]string) Option {
+	return func(o *options) {
+		for key, value := range headers {
+			if key == "" || strings.ContainsAny(key, "\r\n:") || strings.ContainsAny(value, "\r\n") || strings.EqualFold(key, "Host") {
+				continue
+			}
+			if o.headers == nil {
+				o.headers = make(map[string]string, len(headers))
+			}
+			o.headers[key] = value
+		}
+	}
+}
+
+// WithRetries sets the number of additional attempts after the first.
+// Negative values keep the default of 3.
+func WithRetries(n int) Option {
+	return func(o *options) {
+		if n >= 0 {
+			o.retries = n
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry, which doubles on every
+// further retry. Negative values keep the default of one second.
+func WithBackoff(d time.Duration) Option {
+	return func(o *options) {
+		if d >= 0 {
+			o.backoff = d
+		}
+	}
+}
+
+// WithRetryBodyPattern retries success responses whose body matches re,
+// for proxies that answer with a success status while still indexing.
+func WithRetryBodyPattern(re *regexp.Regexp) Option {
+	return func(o *options) {
+		o.bodyPattern = re
+	}
+}
+
+// WithUserAgent sets the User-Agent header. An empty value keeps
+// DefaultUserAgent.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		if userAgent != "" {
+			o.userAgent = userAgent
+		}
+	}
+}
+
+// WithBearerToken authenticates requests with a bearer token. It takes
+// precedence over WithBasicAuth.
+func WithBearerToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithBasicAuth authenticates requests with HTTP Basic auth when both the
+// username and password are set.
+func WithBasicAuth(username, password string) Option {
+	return func(o *options) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithClient sends requests through client instead of the default HTTPS-only
+// client. The client's own timeout applies instead of WithTimeout.
+func WithClient(client HTTPClient) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithLogger sends structured entries about each request to logger. The
+// default discards them.
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithSleep replaces the function that waits between attempts, which
+// defaults to SleepContext. Tests use it to observe the requested delays
+// without waiting for them.
+func WithSleep(sleep func(context.Context, time.Duration) error) Option {
+	return func(o *options) {
+		if sleep != nil {
+			o.sleep = sleep
+		}
+	}
+}
+
+// newClient creates the default client: TLS 1.3 and at most
+// defaultMaxRedirects redirects, all of them to HTTPS URLs.
+func newClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS13}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > defaultMaxRedirects {
+				return fmt.Errorf("too many redirects")
+			}
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to non-HTTPS URL not allowed")
+			}
+			return nil
+		},
+	}
+}
+
+// Result describes a notification on a single proxy.
+type Result struct {
+	ProxyURL   string        // Proxy that was notified
+	StatusCode int           // Status code of the last attempt, or 0 if no response was received
+	Latency    time.Duration // Wall-clock duration of the last attempt
+	Info       *VersionInfo  // Version info returned by the proxy, if the body was valid JSON
+	Attempts   int           // Requests sent to the proxy, including retries
+	Retry      bool          // The last attempt failed in a way worth retrying
+}
+
+// NotifyProxy asks the Go module proxy at proxyURL to fetch modulePath at
+// version, for use outside the plugin's hooks. proxyURL must be an HTTPS URL
+// and defaults to DefaultURL when empty. The version gets a "v" prefix if it
+// has none and must then be a valid version of modulePath, including any
+// +incompatible suffix. Unlike the plugin, NotifyProxy does not block
+// private-network proxy hosts; callers passing untrusted URLs must check
+// them first. Proxy failures are returned as an *Error.
+func NotifyProxy(ctx context.Context, proxyURL, modulePath, version string, opts ...Option) error {
+	if err := module.CheckPath(modulePath); err != nil {
+		return fmt.Errorf("invalid module path: %w", err)
+	}
+	version = NormalizeVersion(version)
+	if err := module.Check(modulePath, version); err != nil {
+		return fmt.Errorf("invalid module version: %w", err)
+	}
+
+	if proxyURL == "" {
+		proxyURL = DefaultURL
+	}
+	if u, err := url.Parse(proxyURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q: must be an https URL", RedactURL(proxyURL))
+	}
+
+	result, err := Notify(ctx, proxyURL, modulePath, version, opts...)
+	if err != nil {
+		return NewError(result, err)
+	}
+	return nil
+}
+
+// Notify asks the proxy at proxyURL to index modulePath at version by
+// requesting its .info endpoint, retrying transient failures: 404, 429 and
+// 500, 502 and 503 responses, connection errors, and success bodies matching
+// the retry body pattern. Retry-After is honoured up to five minutes.
+//
+// Notify does not validate its arguments and returns unclassified errors;
+// NotifyProxy does both. The returned result describes the last attempt and
+// is never nil, even when an error is returned.
+func Notify(ctx context.Context, proxyURL, modulePath, version string, opts ...Option) (*Result, error) {
+	o := newOptions(opts)
+	result := &Result{ProxyURL: proxyURL}
+
+	// Build the proxy URL: {proxy_url}/{module}/@v/{version}.info
+	requestURL := ModuleURL(proxyURL, modulePath, VersionEndpoint(version, ".info"))
+
+	var lastErr error
+	var outcome attemptOutcome
+	for attempt := 0; attempt <= o.retries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("request aborted: %w", err)
+		}
+		if attempt > 0 {
+			if err := o.sleep(ctx, retryWait(outcome, o.backoff, attempt)); err != nil {
+				return result, fmt.Errorf("retry aborted: %w", err)
+			}
+			metrics.IncRetry(RedactURL(proxyURL))
+		}
+
+		start := time.Now()
+		var err error
+		outcome, err = sendRequest(ctx, o, requestURL)
+		result.StatusCode = outcome.StatusCode
+		result.Latency = time.Since(start)
+		result.Info = outcome.Info
+		result.Attempts = attempt + 1
+		result.Retry = outcome.Retry
+		metrics.ObserveRequest(RedactURL(proxyURL), outcome.StatusCode, result.Latency)
+		o.logger.Log(levelDebug, "proxy request", map[string]any{
+			"url":         RedactURL(requestURL),
+			"method":      http.MethodGet,
+			"status":      outcome.StatusCode,
+			"attempt":     attempt + 1,
+			"duration_ms": result.Latency.Milliseconds(),
+		})
+		if !outcome.Retry {
+			logResult(o, requestURL, attempt+1, err)
+			return result, err
+		}
+		lastErr = err
+	}
+
+	if attempts := o.retries + 1; attempts > 1 {
+		lastErr = fmt.Errorf("giving up after %d attempts: %w", attempts, lastErr)
+	}
+	logResult(o, requestURL, o.retries+1, lastErr)
+	return result, lastErr
+}
+
+// logResult logs the final outcome of a notification.
+func logResult(o *options, requestURL string, attempts int, err error) {
+	fields := map[string]any{
+		"url":      RedactURL(requestURL),
+		"attempts": attempts,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		o.logger.Log(levelError, "proxy notification failed", fields)
+		return
+	}
+	o.logger.Log(levelInfo, "proxy notified", fields)
+}
+
+// attemptOutcome describes the result of a single proxy request.
+type attemptOutcome struct {
+	StatusCode int           // HTTP status code, or 0 if no response was received
+	Retry      bool          // Failure is worth retrying
+	RetryAfter time.Duration // Delay requested by the proxy before retrying, if any
+	Info       *VersionInfo  // Decoded success body, or nil if it was not version info JSON
+}
+
+// sendRequest performs a single request against the proxy and reports
+// whether a failed attempt is worth retrying.
+func sendRequest(ctx context.Context, o *options, requestURL string) (attemptOutcome, error) {
+	resp, body, err := do(ctx, o, http.MethodGet, requestURL)
+	if err != nil {
+		return attemptOutcome{}, err
+	}
+
+	// Handle response status codes.
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		// 404 - module or version not found yet.
+		// This can happen if the tag hasn't propagated to the origin, so retry.
+		return attemptOutcome{StatusCode: resp.StatusCode, Retry: true}, fmt.Errorf("module or version not found (404): %s - the tag may need time to propagate", string(body))
+	case http.StatusGone:
+		// 410 - version doesn't exist or has been removed.
+		return attemptOutcome{StatusCode: resp.StatusCode}, fmt.Errorf("version does not exist or is unavailable (410): %s", string(body))
+	case http.StatusTooManyRequests:
+		// 429 - rate limited; wait as long as the proxy asks before retrying.
+		retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return attemptOutcome{StatusCode: resp.StatusCode, Retry: true, RetryAfter: retryAfter},
+			fmt.Errorf("proxy rate limited the request (429): %s", string(body))
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
+		// Transient server-side failures are worth retrying, after the
+		// delay the proxy asks for if it sent one (typically with 503).
+		retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return attemptOutcome{StatusCode: resp.StatusCode, Retry: true, RetryAfter: retryAfter},
+			fmt.Errorf("proxy returned error status %d: %s", resp.StatusCode, string(body))
+	default:
+		if resp.StatusCode >= 400 {
+			return attemptOutcome{StatusCode: resp.StatusCode}, fmt.Errorf("proxy returned error status %d: %s", resp.StatusCode, string(body))
+		}
+		// Some proxies answer with a success status while still indexing.
+		if o.bodyPattern != nil && o.bodyPattern.Match(body) {
+			return attemptOutcome{StatusCode: resp.StatusCode, Retry: true}, fmt.Errorf("proxy reported the version is not ready (status %d): %s", resp.StatusCode, string(body))
+		}
+		// Other 2xx/3xx status codes are acceptable. The body is informational
+		// only, so a malformed one does not fail the notification.
+		outcome := attemptOutcome{StatusCode: resp.StatusCode}
+		var info VersionInfo
+		if err := json.Unmarshal(body, &info); err == nil {
+			outcome.Info = &info
+		} else if len(body) > 0 {
+			o.logger.Log(levelWarn, "proxy returned a version info body that is not JSON", map[string]any{
+				"url":    RedactURL(requestURL),
+				"status": resp.StatusCode,
+				"error":  err.Error(),
+			})
+		}
+		return outcome, nil
+	}
+}
+
+// Do issues a request with the given method, User-Agent, headers and
+// credentials to a proxy and returns the response together with its fully
+// read body. Only the client, timeout, header and authentication options
+// apply.
+func Do(ctx context.Context, method, requestURL string, opts ...Option) (*http.Response, []byte, error) {
+	return do(ctx, newOptions(opts), method, requestURL)
+}
+
+// do implements Do.
+func do(ctx context.Context, o *options, method, requestURL string) (*http.Response, []byte, error) {
+	// Create HTTP request.
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", o.userAgent)
+	for key, value := range o.headers {
+		req.Header.Set(key, value)
+	}
+	switch {
+	case o.token != "":
+		req.Header.Set("Authorization", "Bearer "+o.token)
+	case o.username != "" && o.password != "":
+		req.SetBasicAuth(o.username, o.password)
+	}
+
+	// Send request.
+	resp, err := doContext(ctx, o.client, req)
+	if err != nil {
+		// Report the caller's cancellation or deadline rather than a transport error.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, fmt.Errorf("request aborted: %w", ctxErr)
+		}
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Read response body for error messages.
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return resp, body, nil
+}
+
+// doContext sends req and returns as soon as ctx is done, even if client
+// ignores the request's context (http.Client honours it; injected clients
+// may not). A response arriving after that is closed and discarded.
+func doContext(ctx context.Context, client HTTPClient, req *http.Request) (*http.Response, error) {
+	type doResult struct {
+		resp *http.Response
+		err  error
+	}
+	done := make(chan doResult, 1)
+	go func() {
+		resp, err := client.Do(req)
+		done <- doResult{resp, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.resp, r.err
+	case <-ctx.Done():
+		// Prefer a response that raced with the cancellation.
+		select {
+		case r := <-done:
+			return r.resp, r.err
+		default:
+		}
+		go func() {
+			if r := <-done; r.resp != nil {
+				_ = r.resp.Body.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
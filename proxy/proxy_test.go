@@ -0,0 +1,423 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockHTTPClient implements HTTPClient for testing.
+type mockHTTPClient struct {
+	DoFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if m.DoFunc != nil {
+		return m.DoFunc(req)
+	}
+	return nil, fmt.Errorf("mock not configured")
+}
+
+// mockResponse creates an HTTP response with the given status and body.
+func mockResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+	}
+}
+
+// recordingLogger records the messages of the entries it receives.
+type recordingLogger struct {
+	entries []string
+}
+
+func (l *recordingLogger) Log(level, msg string, _ map[string]any) {
+	l.entries = append(l.entries, level+": "+msg)
+}
+
+func TestNotifyProxy(t *testing.T) {
+	tests := []struct {
+		name          string
+		proxyURL      string
+		modulePath    string
+		version       string
+		opts          []Option
+		statuses      []int
+		expectedCalls int
+		expectedURL   string
+		errContains   string
+		expectedKind  string
+	}{
+		{
+			name:          "success",
+			proxyURL:      "https://goproxy.example.com",
+			modulePath:    "github.com/example/module",
+			version:       "1.2.3",
+			statuses:      []int{http.StatusOK},
+			expectedCalls: 1,
+			expectedURL:   "https://goproxy.example.com/github.com/example/module/@v/v1.2.3.info",
+		},
+		{
+			name:          "default proxy",
+			modulePath:    "github.com/example/module",
+			version:       "v1.2.3",
+			statuses:      []int{http.StatusOK},
+			expectedCalls: 1,
+			expectedURL:   "https://proxy.golang.org/github.com/example/module/@v/v1.2.3.info",
+		},
+		{
+			name:          "incompatible version",
+			proxyURL:      "https://goproxy.example.com",
+			modulePath:    "github.com/example/module",
+			version:       "v3.0.0+incompatible",
+			statuses:      []int{http.StatusOK},
+			expectedCalls: 1,
+			expectedURL:   "https://goproxy.example.com/github.com/example/module/@v/v3.0.0%2Bincompatible.info",
+		},
+		{
+			name:          "retries until success",
+			proxyURL:      "https://goproxy.example.com",
+			modulePath:    "github.com/example/module",
+			version:       "v1.2.3",
+			opts:          []Option{WithRetries(2)},
+			statuses:      []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			expectedCalls: 3,
+		},
+		{
+			name:          "no retries",
+			proxyURL:      "https://goproxy.example.com",
+			modulePath:    "github.com/example/module",
+			version:       "v1.2.3",
+			opts:          []Option{WithRetries(0)},
+			statuses:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedCalls: 1,
+			errContains:   "503",
+			expectedKind:  "server_error",
+		},
+		{
+			name:          "not found",
+			proxyURL:      "https://goproxy.example.com",
+			modulePath:    "github.com/example/module",
+			version:       "v1.2.3",
+			opts:          []Option{WithRetries(0)},
+			statuses:      []int{http.StatusNotFound},
+			expectedCalls: 1,
+			expectedKind:  "not_found",
+		},
+		{
+			name:        "invalid module path",
+			proxyURL:    "https://goproxy.example.com",
+			modulePath:  "",
+			version:     "v1.2.3",
+			errContains: "invalid module path",
+		},
+		{
+			name:        "invalid version",
+			proxyURL:    "https://goproxy.example.com",
+			modulePath:  "github.com/example/module",
+			version:     "release-1",
+			errContains: "invalid module version",
+		},
+		{
+			name:        "major version without suffix",
+			proxyURL:    "https://goproxy.example.com",
+			modulePath:  "github.com/example/module",
+			version:     "v2.0.0",
+			errContains: "invalid module version",
+		},
+		{
+			name:        "plain HTTP proxy",
+			proxyURL:    "http://goproxy.example.com",
+			modulePath:  "github.com/example/module",
+			version:     "v1.2.3",
+			errContains: "must be an https URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			var requestedURL string
+			client := &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requestedURL = req.URL.String()
+					status := tt.statuses[min(calls, len(tt.statuses)-1)]
+					calls++
+					return mockResponse(status, `{}`), nil
+				},
+			}
+
+			opts := append([]Option{WithClient(client), WithBackoff(time.Millisecond)}, tt.opts...)
+			err := NotifyProxy(context.Background(), tt.proxyURL, tt.modulePath, tt.version, opts...)
+
+			if tt.errContains == "" && tt.expectedKind == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if tt.errContains != "" && !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %v", tt.errContains, err)
+			}
+
+			if tt.expectedKind != "" {
+				var proxyErr *Error
+				if !errors.As(err, &proxyErr) {
+					t.Fatalf("expected an *Error, got %T", err)
+				}
+				if proxyErr.Kind != tt.expectedKind {
+					t.Errorf("expected kind %q, got %q", tt.expectedKind, proxyErr.Kind)
+				}
+			}
+
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d requests, got %d", tt.expectedCalls, calls)
+			}
+			if tt.expectedURL != "" && requestedURL != tt.expectedURL {
+				t.Errorf("expected URL %s, got %s", tt.expectedURL, requestedURL)
+			}
+		})
+	}
+}
+
+func TestNotifyProxySendsHeaders(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		expectedAuth  string
+		expectedAgent string
+	}{
+		{
+			name:          "defaults",
+			expectedAgent: DefaultUserAgent,
+		},
+		{
+			name:          "bearer token wins over basic auth",
+			opts:          []Option{WithBasicAuth("user", "pass"), WithBearerToken("secret"), WithUserAgent("custom/1.0")},
+			expectedAuth:  "Bearer secret",
+			expectedAgent: "custom/1.0",
+		},
+		{
+			name:          "basic auth",
+			opts:          []Option{WithBasicAuth("user", "pass")},
+			expectedAuth:  "Basic dXNlcjpwYXNz",
+			expectedAgent: DefaultUserAgent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+			client := &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					header = req.Header
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			opts := append([]Option{
+				WithClient(client),
+				WithHeaders(map[string]string{"X-Trace": "abc", "Host": "evil.example.com", "X-Bad": "a\r\nb"}),
+			}, tt.opts...)
+			err := NotifyProxy(context.Background(), "https://goproxy.example.com", "github.com/example/module", "v1.0.0", opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if header.Get("X-Trace") != "abc" {
+				t.Errorf("expected X-Trace header, got %q", header.Get("X-Trace"))
+			}
+			if header.Get("X-Bad") != "" || header.Get("Host") != "" {
+				t.Errorf("expected unsafe headers to be dropped, got %v", header)
+			}
+			if got := header.Get("Authorization"); got != tt.expectedAuth {
+				t.Errorf("expected Authorization %q, got %q", tt.expectedAuth, got)
+			}
+			if got := header.Get("User-Agent"); got != tt.expectedAgent {
+				t.Errorf("expected User-Agent %q, got %q", tt.expectedAgent, got)
+			}
+		})
+	}
+}
+
+func TestNotifyResult(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             []Option
+		bodies           []string
+		expectedAttempts int
+		expectedVersion  string
+		expectedRetry    bool
+		expectedLog      string
+		errContains      string
+	}{
+		{
+			name:             "version info",
+			bodies:           []string{`{"Version":"v1.0.0","Time":"2024-01-01T00:00:00Z"}`},
+			expectedAttempts: 1,
+			expectedVersion:  "v1.0.0",
+			expectedLog:      "info: proxy notified",
+		},
+		{
+			name:             "body that is not JSON",
+			bodies:           []string{"ok"},
+			expectedAttempts: 1,
+			expectedLog:      "warn: proxy returned a version info body that is not JSON",
+		},
+		{
+			name:             "body pattern retried until ready",
+			opts:             []Option{WithRetryBodyPattern(regexp.MustCompile("pending"))},
+			bodies:           []string{"pending", `{"Version":"v1.0.0"}`},
+			expectedAttempts: 2,
+			expectedVersion:  "v1.0.0",
+			expectedLog:      "info: proxy notified",
+		},
+		{
+			name:             "body pattern never clears",
+			opts:             []Option{WithRetries(1), WithRetryBodyPattern(regexp.MustCompile("pending"))},
+			bodies:           []string{"pending"},
+			expectedAttempts: 2,
+			expectedRetry:    true,
+			expectedLog:      "error: proxy notification failed",
+			errContains:      "giving up after 2 attempts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					body := tt.bodies[min(calls, len(tt.bodies)-1)]
+					calls++
+					return mockResponse(http.StatusOK, body), nil
+				},
+			}
+			logger := &recordingLogger{}
+
+			opts := append([]Option{WithClient(client), WithBackoff(time.Millisecond), WithLogger(logger)}, tt.opts...)
+			result, err := Notify(context.Background(), "https://goproxy.example.com", "github.com/example/module", "v1.0.0", opts...)
+
+			if tt.errContains == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Fatalf("expected error containing '%s', got: %v", tt.errContains, err)
+			}
+
+			if result.Attempts != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectedAttempts, result.Attempts)
+			}
+			if result.Retry != tt.expectedRetry {
+				t.Errorf("expected retry=%v, got %v", tt.expectedRetry, result.Retry)
+			}
+			var version string
+			if result.Info != nil {
+				version = result.Info.Version
+			}
+			if version != tt.expectedVersion {
+				t.Errorf("expected indexed version %q, got %q", tt.expectedVersion, version)
+			}
+			if !strings.Contains(strings.Join(logger.entries, "\n"), tt.expectedLog) {
+				t.Errorf("expected log entry %q, got %v", tt.expectedLog, logger.entries)
+			}
+		})
+	}
+}
+
+func TestNotifyStopsOnCancelledContext(t *testing.T) {
+	calls := 0
+	client := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := Notify(ctx, "https://goproxy.example.com", "github.com/example/module", "v1.0.0", WithClient(client))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no requests, got %d", calls)
+	}
+	if kind := NewError(result, err).Kind; kind != "canceled" {
+		t.Errorf("expected kind canceled, got %s", kind)
+	}
+}
+
+func TestNotifyWithSleep(t *testing.T) {
+	calls := 0
+	client := &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				resp := mockResponse(http.StatusServiceUnavailable, "maintenance")
+				resp.Header.Set("Retry-After", "120")
+				return resp, nil
+			}
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	var waits []time.Duration
+	sleep := func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	if _, err := Notify(context.Background(), "https://goproxy.example.com", "github.com/example/module", "v1.0.0", WithClient(client), WithSleep(sleep)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(waits) != 1 || waits[0] != 2*time.Minute {
+		t.Errorf("expected a single 2m wait, got %v", waits)
+	}
+}
+
+func TestNotifyProxyOptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		opt             Option
+		expectedTimeout time.Duration
+		expectedRetries int
+		expectedBackoff time.Duration
+	}{
+		{name: "timeout", opt: WithTimeout(5 * time.Second), expectedTimeout: 5 * time.Second, expectedRetries: defaultRetries, expectedBackoff: defaultBackoff},
+		{name: "non-positive timeout keeps default", opt: WithTimeout(0), expectedTimeout: defaultTimeout, expectedRetries: defaultRetries, expectedBackoff: defaultBackoff},
+		{name: "retries", opt: WithRetries(7), expectedTimeout: defaultTimeout, expectedRetries: 7, expectedBackoff: defaultBackoff},
+		{name: "negative retries keep default", opt: WithRetries(-1), expectedTimeout: defaultTimeout, expectedRetries: defaultRetries, expectedBackoff: defaultBackoff},
+		{name: "zero backoff", opt: WithBackoff(0), expectedTimeout: defaultTimeout, expectedRetries: defaultRetries, expectedBackoff: 0},
+		{name: "negative backoff keeps default", opt: WithBackoff(-time.Second), expectedTimeout: defaultTimeout, expectedRetries: defaultRetries, expectedBackoff: defaultBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOptions([]Option{tt.opt})
+			if o.timeout != tt.expectedTimeout {
+				t.Errorf("expected timeout %v, got %v", tt.expectedTimeout, o.timeout)
+			}
+			if o.retries != tt.expectedRetries {
+				t.Errorf("expected retries %d, got %d", tt.expectedRetries, o.retries)
+			}
+			if o.backoff != tt.expectedBackoff {
+				t.Errorf("expected backoff %v, got %v", tt.expectedBackoff, o.backoff)
+			}
+			if client, ok := o.client.(*http.Client); !ok || client.Timeout != tt.expectedTimeout {
+				t.Errorf("expected a default client with timeout %v, got %#v", tt.expectedTimeout, o.client)
+			}
+		})
+	}
+}
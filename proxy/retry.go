@@ -0,0 +1,96 @@
+package proxy
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Upper bound for a single exponential backoff delay.
+const maxRetryBackoff = time.Minute
+
+// Upper bound for a delay requested by the proxy through Retry-After.
+const maxRetryAfter = 5 * time.Minute
+
+// parseRetryAfter parses a Retry-After header value given either as a number
+// of seconds or as an HTTP date. Dates in the past yield a zero delay.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// retryWait returns how long to wait before the given retry attempt. The
+// delay requested by the proxy through Retry-After wins over our own
+// backoff, up to maxRetryAfter; the caller's deadline still bounds the
+// actual sleep.
+func retryWait(outcome attemptOutcome, base time.Duration, attempt int) time.Duration {
+	if outcome.RetryAfter > 0 {
+		return min(outcome.RetryAfter, maxRetryAfter)
+	}
+	return withJitter(retryBackoff(base, attempt))
+}
+
+// retryBackoff returns the delay before the given retry attempt (starting at 1),
+// doubling the base delay on every attempt up to maxRetryBackoff:
+//
+//	backoff = min(base * 2^(attempt-1), maxRetryBackoff)
+//
+// The retry loop sleeps for withJitter(backoff), a uniformly random duration
+// in [0, backoff] ("full jitter"), so clients retrying together spread out.
+func retryBackoff(base time.Duration, attempt int) time.Duration {
+	backoff := base
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff)
+}
+
+// rng supplies the randomness for retry jitter.
+// Can be overridden in tests.
+var (
+	rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	rngMu sync.Mutex
+)
+
+// withJitter returns a uniformly random duration in [0, d].
+func withJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	return time.Duration(rng.Int63n(int64(d) + 1))
+}
+
+// SleepContext waits for the given duration or until the context is done,
+// returning the context's error in the latter case.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
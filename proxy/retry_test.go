@@ -0,0 +1,112 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRetryWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome attemptOutcome
+		minWait time.Duration
+		maxWait time.Duration
+	}{
+		{name: "Retry-After honored", outcome: attemptOutcome{RetryAfter: 2 * time.Second}, minWait: 2 * time.Second, maxWait: 2 * time.Second},
+		{name: "absurd Retry-After capped", outcome: attemptOutcome{RetryAfter: 24 * time.Hour}, minWait: maxRetryAfter, maxWait: maxRetryAfter},
+		{name: "backoff without Retry-After", outcome: attemptOutcome{}, minWait: 0, maxWait: 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait := retryWait(tt.outcome, 100*time.Millisecond, 1)
+			if wait < tt.minWait || wait > tt.maxWait {
+				t.Errorf("expected wait in [%v, %v], got %v", tt.minWait, tt.maxWait, wait)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "seconds", value: "5", expected: 5 * time.Second, ok: true},
+		{name: "zero seconds", value: "0", expected: 0, ok: true},
+		{name: "http date", value: "Mon, 01 Jan 2024 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "http date in the past", value: "Mon, 01 Jan 2024 11:00:00 GMT", expected: 0, ok: true},
+		{name: "empty", value: "", expected: 0, ok: false},
+		{name: "negative", value: "-1", expected: 0, ok: false},
+		{name: "garbage", value: "soon", expected: 0, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 1, expected: time.Second},
+		{attempt: 2, expected: 2 * time.Second},
+		{attempt: 3, expected: 4 * time.Second},
+		{attempt: 60, expected: maxRetryBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt_%d", tt.attempt), func(t *testing.T) {
+			if got := retryBackoff(time.Second, tt.attempt); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	originalRNG := rng
+	defer func() { rng = originalRNG }()
+
+	// A seeded source makes the jitter deterministic.
+	rng = rand.New(rand.NewSource(42))
+	expected := rand.New(rand.NewSource(42))
+
+	backoff := 2 * time.Second
+	for i := 0; i < 100; i++ {
+		got := withJitter(backoff)
+		if want := time.Duration(expected.Int63n(int64(backoff) + 1)); got != want {
+			t.Fatalf("iteration %d: expected %v, got %v", i, want, got)
+		}
+		if got < 0 || got > backoff {
+			t.Fatalf("iteration %d: jitter %v outside [0, %v]", i, got, backoff)
+		}
+	}
+
+	if got := withJitter(0); got != 0 {
+		t.Errorf("expected no jitter for a zero backoff, got %v", got)
+	}
+}
+
+func TestSleepContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := SleepContext(ctx, time.Hour); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+}
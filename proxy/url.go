@@ -0,0 +1,66 @@
+package proxy
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ModuleURL builds the URL of a module endpoint on a proxy, e.g.
+// {proxy_url}/{module}/@v/{version}.info for the "@v/{version}.info" endpoint.
+// The module path is case-encoded and percent-encoded element by element;
+// endpoints naming a version should be built with VersionEndpoint.
+func ModuleURL(proxyURL, modulePath, endpoint string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(proxyURL, "/"), encodeModulePath(modulePath), endpoint)
+}
+
+// encodeModulePath encodes a module path for use in a proxy URL, applying
+// the case-encoding and then percent-encoding to each path element, so
+// "github.com/Azure/azure-sdk-for-go" becomes "github.com/!azure/azure-sdk-for-go".
+func encodeModulePath(modulePath string) string {
+	elems := strings.Split(modulePath, "/")
+	for i, elem := range elems {
+		elems[i] = pathEscape(EscapePath(elem))
+	}
+	return strings.Join(elems, "/")
+}
+
+// VersionEndpoint returns the @v/{version}{ext} endpoint for a version, e.g.
+// "@v/v1.0.0.info". The version is case-encoded and percent-encoded so that
+// build metadata such as +incompatible survives as %2B.
+func VersionEndpoint(version, ext string) string {
+	encoded := strings.ReplaceAll(pathEscape(EscapePath(version)), "+", "%2B")
+	return "@v/" + encoded + ext
+}
+
+// pathEscape is url.PathEscape, except that the "!" of the case-encoding is
+// left as is, as the go command sends it.
+func pathEscape(s string) string {
+	return strings.ReplaceAll(url.PathEscape(s), "%21", "!")
+}
+
+// EscapePath applies the module proxy case-encoding to a module path or
+// version: every uppercase letter is replaced by "!" followed by its lowercase
+// form, so "github.com/Azure/sdk" becomes "github.com/!azure/sdk". This keeps
+// URLs unambiguous on case-insensitive file systems.
+func EscapePath(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if 'A' <= r && r <= 'Z' {
+			b.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// RedactURL masks the password of any user credentials embedded in a URL,
+// so it can be logged. Unparsable input is returned unchanged.
+func RedactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return u.Redacted()
+}
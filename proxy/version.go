@@ -0,0 +1,24 @@
+package proxy
+
+import (
+	"strings"
+)
+
+// VersionInfo is the JSON document served by the proxy's .info and @latest endpoints.
+type VersionInfo struct {
+	Version string // Canonical version
+	Time    string // Time the version was recorded by the origin
+}
+
+// NormalizeVersion adds the "v" prefix Go module versions require, so that
+// a release version of "1.2.3" becomes "v1.2.3". Versions that already start
+// with "v" and the empty string are returned unchanged. The input is
+// otherwise taken verbatim: surrounding whitespace is not trimmed and a
+// capital "V" is not treated as the prefix, since neither is a valid module
+// version and semver validation should reject them rather than hide them.
+func NormalizeVersion(version string) string {
+	if version == "" || strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
@@ -0,0 +1,30 @@
+package proxy
+
+import (
+	"testing"
+)
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		expected string
+	}{
+		{name: "missing prefix", version: "1.2.3", expected: "v1.2.3"},
+		{name: "existing prefix", version: "v1.2.3", expected: "v1.2.3"},
+		{name: "prerelease", version: "2.0.0-rc.1", expected: "v2.0.0-rc.1"},
+		{name: "empty", version: "", expected: ""},
+		{name: "capital V is not a prefix", version: "V1.0.0", expected: "vV1.0.0"},
+		{name: "whitespace is kept", version: " 1.0.0 ", expected: "v 1.0.0 "},
+		{name: "pseudo-version", version: "v0.0.0-20240101000000-abcdef123456", expected: "v0.0.0-20240101000000-abcdef123456"},
+		{name: "unprefixed pseudo-version", version: "0.0.0-20240101000000-abcdef123456", expected: "v0.0.0-20240101000000-abcdef123456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeVersion(tt.version); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
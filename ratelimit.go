@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+)
+
+// rateLimiter is a token bucket holding a single token, refilled perSec
+// times per second. Callers that find the bucket empty wait their turn, so
+// requests are spaced evenly rather than sent in bursts.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Time to refill one token
+	next     time.Time     // When the next token becomes available
+}
+
+// newRateLimiter returns a limiter allowing perSec events per second, or nil
+// (no limit) if perSec is not positive.
+func newRateLimiter(perSec int) *rateLimiter {
+	if perSec <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Second / time.Duration(perSec)}
+}
+
+// wait blocks until the caller may proceed or ctx is done. A nil limiter
+// never blocks.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+
+	// Reserve the next token, then sleep until it is available.
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	at := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	return proxy.SleepContext(ctx, at.Sub(now))
+}
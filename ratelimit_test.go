@@ -0,0 +1,238 @@
+// Package main provides tests for parallel and rate-limited notifications.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestRateLimiterSpacesEvents(t *testing.T) {
+	limiter := newRateLimiter(50) // One token every 20ms.
+
+	start := time.Now()
+	for range 4 {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected at least 60ms for 4 events at 50/s, got %v", elapsed)
+	}
+}
+
+func TestRateLimiterRespectsContext(t *testing.T) {
+	limiter := newRateLimiter(1)
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestNilRateLimiterNeverBlocks(t *testing.T) {
+	if limiter := newRateLimiter(0); limiter != nil {
+		t.Fatalf("expected no limiter for a zero rate, got %+v", limiter)
+	}
+	var limiter *rateLimiter
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// modulePaths returns n distinct module paths.
+func modulePaths(n int) []any {
+	paths := make([]any, n)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("github.com/org/mono/m%d", i)
+	}
+	return paths
+}
+
+func TestExecuteMaxConcurrency(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name           string
+		maxConcurrency any
+		expectedMax    int32
+	}{
+		{name: "serialized", maxConcurrency: 1, expectedMax: 1},
+		{name: "parallel", maxConcurrency: 3, expectedMax: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, maxInFlight atomic.Int32
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					n := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					for {
+						seen := maxInFlight.Load()
+						if n <= seen || maxInFlight.CompareAndSwap(seen, n) {
+							break
+						}
+					}
+					// Hold the request so parallel workers overlap.
+					time.Sleep(20 * time.Millisecond)
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":     modulePaths(6),
+					"max_concurrency": tt.maxConcurrency,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if got := maxInFlight.Load(); got > tt.expectedMax {
+				t.Errorf("expected at most %d requests in flight, got %d", tt.expectedMax, got)
+			}
+			if tt.expectedMax == 1 && maxInFlight.Load() != 1 {
+				t.Errorf("expected requests to be serialized, got %d in flight", maxInFlight.Load())
+			}
+			if results, _ := resp.Outputs["results"].(map[string]any); len(results) != 6 {
+				t.Errorf("expected results for 6 modules, got %d", len(results))
+			}
+		})
+	}
+}
+
+func TestExecuteRateLimitPerSec(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	var mu sync.Mutex
+	var starts []time.Time
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			starts = append(starts, time.Now())
+			mu.Unlock()
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	start := time.Now()
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"module_path":        modulePaths(4),
+			"max_concurrency":    4,
+			"rate_limit_per_sec": 20, // One notification every 50ms.
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+	if len(starts) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(starts))
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected throttling to take at least 150ms, got %v", elapsed)
+	}
+}
+
+func TestExecuteRateLimitRespectsDeadline(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	var requests atomic.Int32
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests.Add(1)
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	p := &GoModPlugin{}
+	resp, err := p.Execute(ctx, plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"module_path":        modulePaths(3),
+			"rate_limit_per_sec": 1,
+		},
+		Context: plugin.ReleaseContext{Version: "1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected failure when the deadline expires while throttled")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request before the deadline, got %d", got)
+	}
+	if !strings.Contains(resp.Error, "rate limit wait aborted") {
+		t.Errorf("expected rate limit error, got: %s", resp.Error)
+	}
+}
+
+func TestValidateConcurrencyOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantField string
+	}{
+		{name: "valid", config: map[string]any{"max_concurrency": 2, "rate_limit_per_sec": 5}},
+		{name: "zero concurrency", config: map[string]any{"max_concurrency": 0}, wantField: "max_concurrency"},
+		{name: "negative rate", config: map[string]any{"rate_limit_per_sec": -1}, wantField: "rate_limit_per_sec"},
+	}
+
+	p := &GoModPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["module_path"] = "github.com/user/repo"
+			resp, err := p.Validate(context.Background(), tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantField == "" {
+				if !resp.Valid {
+					t.Errorf("expected valid config, got errors: %v", resp.Errors)
+				}
+				return
+			}
+			if resp.Valid {
+				t.Fatal("expected invalid config")
+			}
+			if resp.Errors[0].Field != tt.wantField {
+				t.Errorf("expected error on field %s, got %s", tt.wantField, resp.Errors[0].Field)
+			}
+		})
+	}
+}
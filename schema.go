@@ -0,0 +1,413 @@
+package main
+
+import (
+	"encoding/json"
+)
+
+// configOption describes a single plugin configuration key.
+type configOption struct {
+	Key         string   // Configuration key
+	Types       []string // JSON schema type(s) accepted for the key
+	Items       string   // Item type when Types includes "array"
+	Description string   // Human-readable description
+	Default     any      // Default value, if any
+	Required    bool     // Whether the key must be provided
+}
+
+// configOptions lists every supported configuration key. The JSON schema
+// reported by GetInfo is generated from this table, so new options only need
+// to be registered here to be documented.
+var configOptions = []configOption{
+	{
+		Key:         "module_path",
+		Types:       []string{"string", "array"},
+		Items:       "string",
+		Description: "Full Go module path (e.g., github.com/user/repo, or use GO_MODULE_PATH env), or a comma-separated list or array of paths; detected from go.mod when unset",
+	},
+	{
+		Key:         "go_mod_path",
+		Types:       []string{"string"},
+		Description: "Path to the go.mod file used to detect module_path",
+		Default:     defaultGoModPath,
+	},
+	{
+		Key:         "from_workspace",
+		Types:       []string{"boolean"},
+		Description: "When module_path is unset, publish every module named by the use directives of the go.work next to go_mod_path, falling back to go.mod",
+		Default:     false,
+	},
+	{
+		Key:         "version_file",
+		Types:       []string{"string"},
+		Description: "File containing the release version, used when the release context has none. Git describe suffixes are stripped",
+	},
+	{
+		Key:         "versions",
+		Types:       []string{"string", "array"},
+		Items:       "string",
+		Description: "Comma-separated list or array of versions to publish in one run instead of the release version; each is normalized and validated separately",
+	},
+	{
+		Key:         "strict_host",
+		Types:       []string{"boolean"},
+		Description: "Reject module paths whose host is neither a known module host nor ends in a recognized top-level domain, catching typos like github.con",
+		Default:     false,
+	},
+	{
+		Key:         "proxy_url",
+		Types:       []string{"string", "array"},
+		Items:       "string",
+		Description: "Go module proxy list in GOPROXY format, as a comma-separated string or array; 'off' or 'direct' skips notification. Falls back to the GOPROXY env, then the default",
+		Default:     defaultProxyURL,
+	},
+	{
+		Key:         "private",
+		Types:       []string{"boolean"},
+		Description: "Skip proxy notification for private modules; when set, overrides private_patterns",
+		Default:     false,
+	},
+	{
+		Key:         "private_patterns",
+		Types:       []string{"string", "array"},
+		Items:       "string",
+		Description: "GOPRIVATE-style globs (e.g. github.com/myorg/*,*.corp.example.com); matching modules skip proxy notification. Falls back to the GOPRIVATE env",
+	},
+	{
+		Key:         "timeout",
+		Types:       []string{"integer"},
+		Description: "Per-attempt request timeout in seconds; each retry gets a fresh timeout. Use total_timeout to bound the whole notification",
+		Default:     defaultTimeout,
+	},
+	{
+		Key:         "total_timeout",
+		Types:       []string{"integer"},
+		Description: "Deadline in seconds for the whole notification, including retries and verification; must not be smaller than timeout (0 disables)",
+		Default:     0,
+	},
+	{
+		Key:         "max_total_duration",
+		Types:       []string{"integer"},
+		Description: "Alias for total_timeout",
+	},
+	{
+		Key:         "max_concurrency",
+		Types:       []string{"integer"},
+		Description: "Maximum number of modules notified in parallel when module_path lists several",
+		Default:     defaultMaxConcurrency,
+	},
+	{
+		Key:         "rate_limit_per_sec",
+		Types:       []string{"integer"},
+		Description: "Maximum number of module notifications started per second when module_path lists several; 0 disables the limit",
+		Default:     0,
+	},
+	{
+		Key:         "max_idle_conns",
+		Types:       []string{"integer"},
+		Description: "Idle HTTP connections kept open across all hosts; 0 removes the limit",
+		Default:     defaultMaxIdleConns,
+	},
+	{
+		Key:         "max_idle_conns_per_host",
+		Types:       []string{"integer"},
+		Description: "Idle HTTP connections kept open per host; 0 uses Go's default of 2",
+		Default:     defaultMaxIdleConnsPerHost,
+	},
+	{
+		Key:         "https_proxy",
+		Types:       []string{"string"},
+		Description: "Egress proxy for outgoing HTTPS requests, e.g. http://proxy.example.com:3128. Falls back to the HTTPS_PROXY env",
+	},
+	{
+		Key:         "no_proxy",
+		Types:       []string{"string"},
+		Description: "Comma-separated hosts, domains and CIDRs reached without the egress proxy. Falls back to the NO_PROXY env",
+	},
+	{
+		Key:         "max_retries",
+		Types:       []string{"integer"},
+		Description: "Number of retries after the first attempt on 404, 429, 500, 502 and 503 responses",
+		Default:     defaultMaxRetries,
+	},
+	{
+		Key:         "retries",
+		Types:       []string{"integer"},
+		Description: "Alias for max_retries",
+	},
+	{
+		Key:         "retry_backoff_ms",
+		Types:       []string{"integer"},
+		Description: "Initial delay between retries in milliseconds, doubled on every retry",
+		Default:     defaultRetryBackoffMs,
+	},
+	{
+		Key:         "retry_body_pattern",
+		Types:       []string{"string"},
+		Description: "Regex that marks a success response body as not ready yet and retries it",
+	},
+	{
+		Key:         "verify",
+		Types:       []string{"boolean"},
+		Description: "Poll the proxy after notifying until it reports the published version, then compare it with @latest",
+		Default:     false,
+	},
+	{
+		Key:         "verify_timeout",
+		Types:       []string{"integer"},
+		Description: "Maximum time to wait for verification in seconds",
+		Default:     defaultVerifyTimeout,
+	},
+	{
+		Key:         "notify_latest",
+		Types:       []string{"boolean"},
+		Description: "Query @latest after notifying so the proxy refreshes its latest pointer; failures only produce a warning",
+		Default:     false,
+	},
+	{
+		Key:         "log_level",
+		Types:       []string{"string"},
+		Description: "Structured log verbosity written to stderr as JSON lines: debug (every proxy request), info, warn or error",
+		Default:     defaultLogLevel,
+	},
+	{
+		Key:         "proxy_token",
+		Types:       []string{"string"},
+		Description: "Bearer token sent to authenticated proxies (or use GO_PROXY_TOKEN / GOPROXY_TOKEN env); never logged",
+	},
+	{
+		Key:         "allow_private_proxy",
+		Types:       []string{"boolean"},
+		Description: "Allow private-network proxy hosts such as on-prem Athens (HTTPS and the localhost block still apply)",
+		Default:     false,
+	},
+	{
+		Key:         "insecure_patterns",
+		Types:       []string{"string", "array"},
+		Items:       "string",
+		Description: "GOINSECURE-style globs of modules that may use an http:// proxy; localhost and private hosts are still blocked unless allow_private_proxy is set. Falls back to the GOINSECURE env",
+	},
+	{
+		Key:         "proxy_username",
+		Types:       []string{"string"},
+		Description: "HTTP Basic auth user for the proxy (requires proxy_password; ignored when proxy_token is set)",
+	},
+	{
+		Key:         "proxy_password",
+		Types:       []string{"string"},
+		Description: "HTTP Basic auth password for the proxy; never logged",
+	},
+	{
+		Key:         "allowed_hosts",
+		Types:       []string{"string", "array"},
+		Items:       "string",
+		Description: "Exact proxy hostnames exempt from the private-network block (HTTPS and the localhost block still apply)",
+	},
+	{
+		Key:         "user_agent",
+		Types:       []string{"string"},
+		Description: "User-Agent sent with proxy requests, e.g. to identify the pipeline in proxy logs",
+		Default:     defaultUserAgent,
+	},
+	{
+		Key:         "headers",
+		Types:       []string{"object"},
+		Description: "Extra HTTP headers sent with proxy requests, e.g. X-Api-Key; the Host header cannot be overridden",
+	},
+	{
+		Key:         "skip_dns_check",
+		Types:       []string{"boolean"},
+		Description: "Skip resolving the proxy host to reject private, loopback, link-local and CGNAT addresses (for air-gapped environments)",
+		Default:     false,
+	},
+	{
+		Key:         "resolve_and_check",
+		Types:       []string{"boolean"},
+		Description: "Resolve the proxy host and reject it if any address is private, loopback, link-local or CGNAT; false is equivalent to skip_dns_check",
+		Default:     true,
+	},
+	{
+		Key:         "check_mod",
+		Types:       []string{"boolean"},
+		Description: "Fetch the version's go.mod from the proxy and fail if its module directive does not match module_path",
+		Default:     false,
+	},
+	{
+		Key:         "verify_gomod",
+		Types:       []string{"boolean"},
+		Description: "Alias for check_mod",
+		Default:     false,
+	},
+	{
+		Key:         "check_zip",
+		Types:       []string{"boolean"},
+		Description: "Send a HEAD request for the version's zip and fail unless the proxy serves it with a non-zero Content-Length",
+		Default:     false,
+	},
+	{
+		Key:         "soft_not_found",
+		Types:       []string{"boolean"},
+		Description: "When the proxy still returns 404 after all retries, succeed with a warning and the pending output instead of failing",
+		Default:     false,
+	},
+	{
+		Key:         "disable_cache",
+		Types:       []string{"boolean"},
+		Description: "Send every notification, even when an identical one already succeeded in the same run (e.g. a module listed twice)",
+		Default:     false,
+	},
+	{
+		Key:         "fail_if_exists",
+		Types:       []string{"boolean"},
+		Description: "Fail before notifying if the proxy's @v/list already contains the version",
+		Default:     false,
+	},
+	{
+		Key:         "check_existing",
+		Types:       []string{"boolean"},
+		Description: "Skip notifying, and succeed, if the proxy's @v/list already contains the version",
+		Default:     false,
+	},
+	{
+		Key:         "auto_version",
+		Types:       []string{"boolean"},
+		Description: "When the release provides no version or tag, use the version reported by the proxy's @latest endpoint",
+		Default:     false,
+	},
+	{
+		Key:         "allow_pseudo",
+		Types:       []string{"boolean"},
+		Description: "Accept pseudo-versions such as v0.0.0-20240101000000-abcdef123456; when false, post-publish rejects them",
+		Default:     true,
+	},
+	{
+		Key:         "check_vanity",
+		Types:       []string{"boolean"},
+		Description: "For modules not hosted on a known VCS host, fetch https://{module}?go-get=1 before notifying and fail unless it serves a valid go-import meta tag",
+		Default:     false,
+	},
+	{
+		Key:         "dry_run_verify",
+		Types:       []string{"boolean"},
+		Description: "In dry runs, perform a read-only @v/list request to check the proxy is reachable and whether the version already exists, without failing the release",
+		Default:     false,
+	},
+	{
+		Key:         "dry_run_connectivity",
+		Types:       []string{"boolean"},
+		Description: "In dry runs, send a single HEAD request to the proxy root and report reachable and tls_version, without touching any module endpoint",
+		Default:     false,
+	},
+	{
+		Key:         "notify_sumdb",
+		Types:       []string{"boolean"},
+		Description: "After notifying the proxy, look the version up in the checksum database to prime it; failures are warnings",
+		Default:     false,
+	},
+	{
+		Key:         "verify_sumdb",
+		Types:       []string{"boolean"},
+		Description: "After notifying, look the version up in the checksum database and fail unless it serves a record for it; reports sumdb_record and sumdb_tree_size",
+		Default:     false,
+	},
+	{
+		Key:         "sumdb_url",
+		Types:       []string{"string"},
+		Description: "Checksum database URL used by notify_sumdb and verify_sumdb",
+		Default:     defaultSumDBURL,
+	},
+	{
+		Key:         "no_sumdb_patterns",
+		Types:       []string{"string", "array"},
+		Items:       "string",
+		Description: "GONOSUMDB-style globs of modules whose checksum database lookup is skipped by notify_sumdb and verify_sumdb. Falls back to the GONOSUMDB env",
+	},
+	{
+		Key:         "warm_pkgsite",
+		Types:       []string{"boolean"},
+		Description: "After notifying, request the version's pkg.go.dev page so its documentation is rendered sooner; failures are warnings",
+		Default:     false,
+	},
+	{
+		Key:         "tls_min_version",
+		Types:       []string{"string"},
+		Description: "Minimum TLS version for proxy connections: \"1.2\" or \"1.3\"",
+		Default:     defaultTLSMinVersion,
+	},
+	{
+		Key:         "max_redirects",
+		Types:       []string{"integer"},
+		Description: "Maximum HTTPS redirects followed per request; 0 disables redirects (non-HTTPS redirects are always rejected)",
+		Default:     defaultMaxRedirects,
+	},
+	{
+		Key:         "restrict_redirect_host",
+		Types:       []string{"boolean"},
+		Description: "Only follow redirects to the host of the original request, so a compromised proxy cannot send requests elsewhere",
+		Default:     false,
+	},
+	{
+		Key:         "disable_http2",
+		Types:       []string{"boolean"},
+		Description: "Pin proxy connections to HTTP/1.1. Connections already use HTTP/1.1; this keeps them on it if HTTP/2 is enabled by default later",
+		Default:     false,
+	},
+	{
+		Key:         "ca_cert_file",
+		Types:       []string{"string"},
+		Description: "PEM file with CA certificates trusted for proxy connections, e.g. for an internal Athens CA",
+	},
+	{
+		Key:         "insecure_skip_verify",
+		Types:       []string{"boolean"},
+		Description: "Skip TLS certificate verification for throwaway test proxies; refused for public proxies such as proxy.golang.org",
+		Default:     false,
+	},
+	{
+		Key:         "client_cert_file",
+		Types:       []string{"string"},
+		Description: "PEM client certificate presented to proxies requiring mutual TLS (requires client_key_file)",
+	},
+	{
+		Key:         "client_key_file",
+		Types:       []string{"string"},
+		Description: "PEM private key for client_cert_file",
+	},
+}
+
+// ConfigSchema returns the effective JSON schema for the plugin configuration.
+func (p *GoModPlugin) ConfigSchema() string {
+	properties := make(map[string]any, len(configOptions))
+	required := []string{}
+
+	for _, opt := range configOptions {
+		property := map[string]any{
+			"description": opt.Description,
+		}
+		if len(opt.Types) == 1 {
+			property["type"] = opt.Types[0]
+		} else {
+			property["type"] = opt.Types
+		}
+		if opt.Items != "" {
+			property["items"] = map[string]any{"type": opt.Items}
+		}
+		if opt.Default != nil {
+			property["default"] = opt.Default
+		}
+
+		properties[opt.Key] = property
+		if opt.Required {
+			required = append(required, opt.Key)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+
+	// Marshalling plain maps, strings and scalars cannot fail.
+	data, _ := json.Marshal(schema)
+	return string(data)
+}
@@ -0,0 +1,62 @@
+// Package main provides tests for the GoMod plugin configuration schema.
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConfigSchema(t *testing.T) {
+	p := &GoModPlugin{}
+
+	var schema struct {
+		Type       string                    `json:"type"`
+		Properties map[string]map[string]any `json:"properties"`
+		Required   []string                  `json:"required"`
+	}
+	if err := json.Unmarshal([]byte(p.ConfigSchema()), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	if schema.Type != "object" {
+		t.Errorf("expected schema type 'object', got '%s'", schema.Type)
+	}
+
+	// Every registered option must be present in the emitted schema.
+	for _, opt := range configOptions {
+		if _, ok := schema.Properties[opt.Key]; !ok {
+			t.Errorf("expected property '%s' in schema", opt.Key)
+		}
+	}
+
+	// Retry options carry their defaults.
+	maxRetries, ok := schema.Properties["max_retries"]
+	if !ok {
+		t.Fatal("expected max_retries property in schema")
+	}
+	if maxRetries["type"] != "integer" {
+		t.Errorf("max_retries: expected type 'integer', got %v", maxRetries["type"])
+	}
+	if maxRetries["default"] != float64(defaultMaxRetries) {
+		t.Errorf("max_retries: expected default %d, got %v", defaultMaxRetries, maxRetries["default"])
+	}
+
+	// proxy_url accepts both a comma-separated string and a list.
+	proxyTypes, ok := schema.Properties["proxy_url"]["type"].([]any)
+	if !ok || len(proxyTypes) != 2 || proxyTypes[0] != "string" || proxyTypes[1] != "array" {
+		t.Errorf("proxy_url: expected type [string array], got %v", schema.Properties["proxy_url"]["type"])
+	}
+
+	// module_path can be detected from go.mod, so nothing is strictly required.
+	if len(schema.Required) != 0 {
+		t.Errorf("expected no required keys, got %v", schema.Required)
+	}
+}
+
+func TestGetInfoUsesConfigSchema(t *testing.T) {
+	p := &GoModPlugin{}
+
+	if p.GetInfo().ConfigSchema != p.ConfigSchema() {
+		t.Error("expected GetInfo to report the generated config schema")
+	}
+}
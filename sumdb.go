@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+)
+
+// Default Go checksum database URL.
+const defaultSumDBURL = "https://sum.golang.org"
+
+// sumDBLookupURL builds the checksum database lookup URL for a module
+// version, e.g. https://sum.golang.org/lookup/github.com/user/repo@v1.0.0.
+// Module path and version are case-encoded as on the proxy.
+func sumDBLookupURL(sumDBURL, modulePath, version string) string {
+	return strings.TrimSuffix(sumDBURL, "/") + "/lookup/" + proxy.EscapePath(modulePath) + "@" + proxy.EscapePath(version)
+}
+
+// notifySumDB looks the version up in the checksum database so it is recorded
+// before downstream consumers ask for it. Proxy credentials are never sent to
+// the checksum database.
+func (p *GoModPlugin) notifySumDB(ctx context.Context, cfg *Config, version string) error {
+	_, err := p.lookupSumDB(ctx, cfg, version)
+	return err
+}
+
+// verifySumDB looks the version up in the checksum database and confirms the
+// response is a record for it.
+func (p *GoModPlugin) verifySumDB(ctx context.Context, cfg *Config, version string) (*sumDBRecord, error) {
+	body, err := p.lookupSumDB(ctx, cfg, version)
+	if err != nil {
+		return nil, err
+	}
+	return parseSumDBRecord(body, cfg.ModulePath, version)
+}
+
+// lookupSumDB fetches the checksum database lookup response for the version.
+func (p *GoModPlugin) lookupSumDB(ctx context.Context, cfg *Config, version string) ([]byte, error) {
+	requestURL := sumDBLookupURL(cfg.SumDBURL, cfg.ModulePath, version)
+	if err := cfg.checkRequestURL(ctx, requestURL); err != nil {
+		return nil, err
+	}
+
+	anonymous := cfg.anonymous()
+	client, err := anonymous.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := proxyGet(ctx, client, anonymous, requestURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("checksum database lookup returned status %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// sumDBRecord is the part of a checksum database lookup response the plugin
+// reports.
+type sumDBRecord struct {
+	HashLine string // go.sum line for the module zip
+	TreeSize int64  // Size of the signed tree the record belongs to
+}
+
+// parseSumDBRecord parses a lookup response: the record number, the go.sum
+// lines for the version, a blank line and the signed tree note, whose second
+// line is the tree size.
+func parseSumDBRecord(body []byte, modulePath, version string) (*sumDBRecord, error) {
+	record, note, ok := strings.Cut(string(body), "\n\n")
+	if !ok {
+		return nil, fmt.Errorf("malformed checksum database record")
+	}
+	lines := strings.Split(record, "\n")
+	if _, err := strconv.ParseInt(lines[0], 10, 64); err != nil {
+		return nil, fmt.Errorf("malformed checksum database record: invalid record number %q", lines[0])
+	}
+
+	var result sumDBRecord
+	for _, line := range lines[1:] {
+		if f := strings.Fields(line); len(f) == 3 && f[0] == modulePath && f[1] == version && strings.HasPrefix(f[2], "h1:") {
+			result.HashLine = line
+		}
+	}
+	if result.HashLine == "" {
+		return nil, fmt.Errorf("checksum database record has no hash for %s@%s", modulePath, version)
+	}
+
+	noteLines := strings.Split(note, "\n")
+	if len(noteLines) < 3 {
+		return nil, fmt.Errorf("malformed checksum database record: missing tree")
+	}
+	size, err := strconv.ParseInt(noteLines[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed checksum database record: invalid tree size %q", noteLines[1])
+	}
+	result.TreeSize = size
+	return &result, nil
+}
+
+// anonymous returns a copy of the configuration without the proxy
+// credentials, custom headers and TLS settings, for requests to hosts other
+// than the proxy. Those hosts are verified against the system roots with the
+// default minimum TLS version, and no client certificate is offered to them.
+func (cfg *Config) anonymous() *Config {
+	anonymous := *cfg
+	anonymous.ProxyToken, anonymous.ProxyUsername, anonymous.ProxyPassword = "", "", ""
+	anonymous.Headers = nil
+	anonymous.CACertFile, anonymous.ClientCertFile, anonymous.ClientKeyFile = "", "", ""
+	anonymous.InsecureSkipVerify = false
+	anonymous.TLSMinVersion = 0
+	return &anonymous
+}
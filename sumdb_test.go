@@ -0,0 +1,383 @@
+// Package main provides tests for checksum database notification.
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestSumDBLookupURL(t *testing.T) {
+	tests := []struct {
+		sumDBURL string
+		expected string
+	}{
+		{
+			sumDBURL: "https://sum.golang.org",
+			expected: "https://sum.golang.org/lookup/github.com/user/repo@v1.2.3",
+		},
+		{
+			sumDBURL: "https://sum.example.com/",
+			expected: "https://sum.example.com/lookup/github.com/user/repo@v1.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sumDBURL, func(t *testing.T) {
+			if got := sumDBLookupURL(tt.sumDBURL, "github.com/user/repo", "v1.2.3"); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	expected := "https://sum.golang.org/lookup/github.com/!azure/sdk@v1.0.0-!r!c.1"
+	if got := sumDBLookupURL(defaultSumDBURL, "github.com/Azure/sdk", "v1.0.0-RC.1"); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestExecuteNotifySumDB(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name             string
+		notifySumDB      bool
+		sumDBStatus      int
+		expectedLookups  int
+		expectedNotified any
+		expectWarning    bool
+	}{
+		{
+			name:             "lookup succeeds",
+			notifySumDB:      true,
+			sumDBStatus:      http.StatusOK,
+			expectedLookups:  1,
+			expectedNotified: true,
+		},
+		{
+			name:             "not found is a warning",
+			notifySumDB:      true,
+			sumDBStatus:      http.StatusNotFound,
+			expectedLookups:  1,
+			expectedNotified: false,
+			expectWarning:    true,
+		},
+		{
+			name:        "disabled",
+			sumDBStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookups []*http.Request
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Host == "sum.golang.org" {
+						lookups = append(lookups, req)
+						return mockResponse(tt.sumDBStatus, "not found"), nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":  "github.com/user/repo",
+					"notify_sumdb": tt.notifySumDB,
+					"proxy_token":  "secret-token",
+				},
+				Context: plugin.ReleaseContext{Version: "1.2.3"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+
+			if len(lookups) != tt.expectedLookups {
+				t.Fatalf("expected %d lookups, got %d", tt.expectedLookups, len(lookups))
+			}
+			for _, lookup := range lookups {
+				if lookup.URL.String() != "https://sum.golang.org/lookup/github.com/user/repo@v1.2.3" {
+					t.Errorf("unexpected lookup URL %s", lookup.URL)
+				}
+				if lookup.Header.Get("Authorization") != "" {
+					t.Error("expected no proxy credentials on the checksum database request")
+				}
+			}
+			if resp.Outputs["sumdb_notified"] != tt.expectedNotified {
+				t.Errorf("expected sumdb_notified %v, got %v", tt.expectedNotified, resp.Outputs["sumdb_notified"])
+			}
+			if got := strings.Contains(resp.Message, "could not notify checksum database"); got != tt.expectWarning {
+				t.Errorf("expected warning=%v, got message: %s", tt.expectWarning, resp.Message)
+			}
+		})
+	}
+}
+
+func TestValidateSumDBURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		sumDBURL  string
+		wantValid bool
+	}{
+		{name: "default", sumDBURL: "https://sum.golang.org", wantValid: true},
+		{name: "http", sumDBURL: "http://sum.golang.org"},
+		{name: "localhost", sumDBURL: "https://localhost"},
+		{name: "private", sumDBURL: "https://10.0.0.1"},
+	}
+
+	p := &GoModPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := p.Validate(context.Background(), map[string]any{
+				"module_path": "github.com/user/repo",
+				"sumdb_url":   tt.sumDBURL,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Fatalf("expected valid=%v, got %v (errors: %v)", tt.wantValid, resp.Valid, resp.Errors)
+			}
+			if !tt.wantValid && resp.Errors[0].Field != "sumdb_url" {
+				t.Errorf("expected error on field sumdb_url, got %s", resp.Errors[0].Field)
+			}
+		})
+	}
+}
+
+func TestExecuteNoSumDBPatterns(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		notifySumDB     bool
+		patterns        string
+		expectedLookups int
+		expectSkipped   bool
+	}{
+		{
+			name:          "matching pattern suppresses the lookup",
+			notifySumDB:   true,
+			patterns:      "github.com/user/*",
+			expectSkipped: true,
+		},
+		{
+			name:            "non-matching pattern performs the lookup",
+			notifySumDB:     true,
+			patterns:        "github.com/other/*",
+			expectedLookups: 1,
+		},
+		{
+			name:     "ignored without notify_sumdb",
+			patterns: "github.com/user/*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Host == "sum.golang.org" {
+						lookups++
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":       "github.com/user/repo",
+					"notify_sumdb":      tt.notifySumDB,
+					"no_sumdb_patterns": tt.patterns,
+				},
+				Context: plugin.ReleaseContext{Version: "1.2.3"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+
+			if lookups != tt.expectedLookups {
+				t.Errorf("expected %d lookups, got %d", tt.expectedLookups, lookups)
+			}
+			if skipped, _ := resp.Outputs["sumdb_skipped"].(bool); skipped != tt.expectSkipped {
+				t.Errorf("expected sumdb_skipped=%v, got %v", tt.expectSkipped, resp.Outputs["sumdb_skipped"])
+			}
+		})
+	}
+}
+
+// sumDBLookupBody is a lookup response in the format served by sum.golang.org.
+const sumDBLookupBody = `3452
+github.com/user/repo v1.2.3 h1:Eq5ERkuqbgK0lZ2YwEo6Ih5oZ8q+TjbL1rAXpA2H7b0=
+github.com/user/repo v1.2.3/go.mod h1:T1dGI8L9m2YdBbR8s0Xz3HTVr7c1UVSPhEhyS8h9dfc=
+
+go.sum database tree
+31416927
+CcDrSE8m6aXnmqJ0xLm4UVjN1uzVQRsrG7UCTKdFz5I=
+
+— sum.golang.org Az3grqrKnrnAGBpCeIlhymMKG7YqG9VUSZ7jpB9eyyZ9LE6b7jnU8gLMW9oKDGtGDFOeSFHc2NJqGcRLvwcQbtIzXAE=
+`
+
+func TestParseSumDBRecord(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		expectedHashLine string
+		expectedTreeSize int64
+		errContains      string
+	}{
+		{
+			name:             "well-formed record",
+			body:             sumDBLookupBody,
+			expectedHashLine: "github.com/user/repo v1.2.3 h1:Eq5ERkuqbgK0lZ2YwEo6Ih5oZ8q+TjbL1rAXpA2H7b0=",
+			expectedTreeSize: 31416927,
+		},
+		{
+			name:        "not a record",
+			body:        "not found: github.com/user/repo@v1.2.3",
+			errContains: "malformed checksum database record",
+		},
+		{
+			name:        "record for another version",
+			body:        strings.ReplaceAll(sumDBLookupBody, "v1.2.3", "v1.2.4"),
+			errContains: "checksum database record has no hash for github.com/user/repo@v1.2.3",
+		},
+		{
+			name:        "invalid tree size",
+			body:        strings.Replace(sumDBLookupBody, "31416927", "lots", 1),
+			errContains: `invalid tree size "lots"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := parseSumDBRecord([]byte(tt.body), "github.com/user/repo", "v1.2.3")
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("expected error containing '%s', got: %v", tt.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if record.HashLine != tt.expectedHashLine {
+				t.Errorf("expected hash line %q, got %q", tt.expectedHashLine, record.HashLine)
+			}
+			if record.TreeSize != tt.expectedTreeSize {
+				t.Errorf("expected tree size %d, got %d", tt.expectedTreeSize, record.TreeSize)
+			}
+		})
+	}
+}
+
+func TestExecuteVerifySumDB(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		sumDBURL        string
+		sumDBStatus     int
+		sumDBBody       string
+		expectedSuccess bool
+		expectedRecord  any
+		errContains     string
+	}{
+		{
+			name:            "record found",
+			sumDBStatus:     http.StatusOK,
+			sumDBBody:       sumDBLookupBody,
+			expectedSuccess: true,
+			expectedRecord:  "github.com/user/repo v1.2.3 h1:Eq5ERkuqbgK0lZ2YwEo6Ih5oZ8q+TjbL1rAXpA2H7b0=",
+		},
+		{
+			name:            "custom checksum database",
+			sumDBURL:        "https://sum.example.com",
+			sumDBStatus:     http.StatusOK,
+			sumDBBody:       sumDBLookupBody,
+			expectedSuccess: true,
+			expectedRecord:  "github.com/user/repo v1.2.3 h1:Eq5ERkuqbgK0lZ2YwEo6Ih5oZ8q+TjbL1rAXpA2H7b0=",
+		},
+		{
+			name:        "not found fails",
+			sumDBStatus: http.StatusNotFound,
+			sumDBBody:   "not found",
+			errContains: "failed to verify checksum database entry: checksum database lookup returned status 404",
+		},
+		{
+			name:        "unparseable record fails",
+			sumDBStatus: http.StatusOK,
+			sumDBBody:   "<html>maintenance</html>",
+			errContains: "malformed checksum database record",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookups int
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if strings.HasPrefix(req.URL.Path, "/lookup/") {
+						lookups++
+						return mockResponse(tt.sumDBStatus, tt.sumDBBody), nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			config := map[string]any{
+				"module_path":  "github.com/user/repo",
+				"verify_sumdb": true,
+			}
+			if tt.sumDBURL != "" {
+				config["sumdb_url"] = tt.sumDBURL
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "1.2.3"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+			if lookups != 1 {
+				t.Errorf("expected 1 lookup, got %d", lookups)
+			}
+			if resp.Outputs["sumdb_record"] != tt.expectedRecord {
+				t.Errorf("expected sumdb_record %v, got %v", tt.expectedRecord, resp.Outputs["sumdb_record"])
+			}
+			if tt.expectedSuccess && resp.Outputs["sumdb_tree_size"] != int64(31416927) {
+				t.Errorf("expected sumdb_tree_size 31416927, got %v", resp.Outputs["sumdb_tree_size"])
+			}
+		})
+	}
+}
@@ -0,0 +1,551 @@
+// Package main provides tests for TLS configuration.
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+// writeTestCert writes a self-signed certificate and its private key as PEM
+// files into a temp directory and returns their paths.
+func writeTestCert(t *testing.T) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, "cert.pem")
+	keyPath = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return certPath, keyPath
+}
+
+// transportFor builds the default client for config and returns its transport.
+func transportFor(t *testing.T, config map[string]any) *http.Transport {
+	t.Helper()
+
+	p := &GoModPlugin{}
+	opts, err := p.parseConfig(config).clientOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	transport, ok := createDefaultHTTPClient(30*time.Second, opts...).Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("expected transport to be *http.Transport")
+	}
+	return transport
+}
+
+func TestCACertFile(t *testing.T) {
+	certPath, _ := writeTestCert(t)
+
+	transport := transportFor(t, map[string]any{"ca_cert_file": certPath})
+	if transport.TLSClientConfig.RootCAs == nil {
+		t.Error("expected RootCAs to be set")
+	}
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("expected TLS 1.3 minimum to be kept, got %x", transport.TLSClientConfig.MinVersion)
+	}
+
+	if transportFor(t, map[string]any{}).TLSClientConfig.RootCAs != nil {
+		t.Error("expected system trust store without ca_cert_file")
+	}
+}
+
+func TestValidateCACertFile(t *testing.T) {
+	certPath, _ := writeTestCert(t)
+
+	notPEM := filepath.Join(t.TempDir(), "not-a-cert.pem")
+	if err := os.WriteFile(notPEM, []byte("hello"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		wantValid bool
+	}{
+		{name: "valid certificate", path: certPath, wantValid: true},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.pem")},
+		{name: "no certificates", path: notPEM},
+	}
+
+	p := &GoModPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := p.Validate(context.Background(), map[string]any{
+				"module_path":  "github.com/user/repo",
+				"ca_cert_file": tt.path,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Fatalf("expected valid=%v, got %v (errors: %v)", tt.wantValid, resp.Valid, resp.Errors)
+			}
+			if !tt.wantValid && resp.Errors[0].Field != "ca_cert_file" {
+				t.Errorf("expected error on field ca_cert_file, got %s", resp.Errors[0].Field)
+			}
+		})
+	}
+}
+
+func TestExecuteFailsOnCACertFileError(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request to %s", req.URL)
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"module_path":  "github.com/user/repo",
+			"ca_cert_file": filepath.Join(t.TempDir(), "missing.pem"),
+		},
+		Context: plugin.ReleaseContext{Version: "v1.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A broken CA bundle must not fall back to the system trust store.
+	if resp.Success {
+		t.Fatal("expected failure for an unreadable ca_cert_file")
+	}
+	if !strings.Contains(resp.Error, "failed to read CA certificate file") {
+		t.Errorf("expected CA file error, got: %s", resp.Error)
+	}
+}
+
+func TestClientCertificate(t *testing.T) {
+	certPath, keyPath := writeTestCert(t)
+
+	transport := transportFor(t, map[string]any{
+		"client_cert_file": certPath,
+		"client_key_file":  keyPath,
+	})
+	if len(transport.TLSClientConfig.Certificates) != 1 {
+		t.Fatalf("expected 1 client certificate, got %d", len(transport.TLSClientConfig.Certificates))
+	}
+
+	// Only one half configured is an error rather than a silent downgrade.
+	p := &GoModPlugin{}
+	for _, config := range []map[string]any{
+		{"client_cert_file": certPath},
+		{"client_key_file": keyPath},
+	} {
+		if _, err := p.parseConfig(config).clientOptions(); err == nil {
+			t.Errorf("expected error for %v", config)
+		}
+	}
+}
+
+func TestAnonymousClientUsesDefaultTLS(t *testing.T) {
+	certPath, keyPath := writeTestCert(t)
+
+	p := &GoModPlugin{}
+	cfg := p.parseConfig(map[string]any{
+		"module_path":          "github.com/user/repo",
+		"proxy_url":            "https://athens.corp.example.com",
+		"ca_cert_file":         certPath,
+		"client_cert_file":     certPath,
+		"client_key_file":      keyPath,
+		"insecure_skip_verify": true,
+		"tls_min_version":      "1.2",
+	})
+	opts, err := cfg.anonymous().clientOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	transport, ok := createDefaultHTTPClient(30*time.Second, opts...).Transport.(*http.Transport)
+	if !ok {
+		t.Fatal("expected transport to be *http.Transport")
+	}
+	tlsConfig := transport.TLSClientConfig
+	if tlsConfig.RootCAs != nil {
+		t.Error("expected the system roots for hosts other than the proxy")
+	}
+	if len(tlsConfig.Certificates) != 0 {
+		t.Errorf("expected no client certificate, got %d", len(tlsConfig.Certificates))
+	}
+	if tlsConfig.InsecureSkipVerify {
+		t.Error("expected certificate verification to stay on")
+	}
+	if tlsConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("expected the default TLS 1.3 minimum, got %x", tlsConfig.MinVersion)
+	}
+}
+
+func TestExecuteFailsOnClientCertificateError(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request to %s", req.URL)
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	certPath, keyPath := writeTestCert(t)
+
+	tests := []struct {
+		name        string
+		config      map[string]any
+		errContains string
+	}{
+		{
+			name:        "mismatched files",
+			config:      map[string]any{"client_cert_file": keyPath, "client_key_file": certPath},
+			errContains: "failed to load client certificate",
+		},
+		{
+			name:        "missing key",
+			config:      map[string]any{"client_cert_file": certPath},
+			errContains: "must be set together",
+		},
+	}
+
+	p := &GoModPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{"module_path": "github.com/user/repo", "max_retries": 0}
+			for key, value := range tt.config {
+				config[key] = value
+			}
+
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "v1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success {
+				t.Fatal("expected failure")
+			}
+			if !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing %q, got: %s", tt.errContains, resp.Error)
+			}
+		})
+	}
+}
+
+func TestValidateClientCertificate(t *testing.T) {
+	certPath, keyPath := writeTestCert(t)
+
+	tests := []struct {
+		name      string
+		config    map[string]any
+		wantValid bool
+		wantField string
+	}{
+		{
+			name:      "cert and key",
+			config:    map[string]any{"client_cert_file": certPath, "client_key_file": keyPath},
+			wantValid: true,
+		},
+		{
+			name:      "cert without key",
+			config:    map[string]any{"client_cert_file": certPath},
+			wantField: "client_key_file",
+		},
+		{
+			name:      "key without cert",
+			config:    map[string]any{"client_key_file": keyPath},
+			wantField: "client_cert_file",
+		},
+		{
+			name:      "mismatched files",
+			config:    map[string]any{"client_cert_file": keyPath, "client_key_file": certPath},
+			wantField: "client_cert_file",
+		},
+	}
+
+	p := &GoModPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{"module_path": "github.com/user/repo"}
+			for key, value := range tt.config {
+				config[key] = value
+			}
+
+			resp, err := p.Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Fatalf("expected valid=%v, got %v (errors: %v)", tt.wantValid, resp.Valid, resp.Errors)
+			}
+			if !tt.wantValid && resp.Errors[0].Field != tt.wantField {
+				t.Errorf("expected error on field %s, got %s", tt.wantField, resp.Errors[0].Field)
+			}
+		})
+	}
+}
+
+func TestInsecureSkipVerify(t *testing.T) {
+	if transportFor(t, map[string]any{}).TLSClientConfig.InsecureSkipVerify {
+		t.Error("expected certificate verification by default")
+	}
+
+	transport := transportFor(t, map[string]any{"insecure_skip_verify": true})
+	if !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("expected InsecureSkipVerify to be set")
+	}
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("expected TLS 1.3 minimum to be kept, got %x", transport.TLSClientConfig.MinVersion)
+	}
+}
+
+func TestExecuteInsecureSkipVerify(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name        string
+		proxyURL    string
+		wantSuccess bool
+		errContains string
+	}{
+		{
+			name:        "test proxy warns",
+			proxyURL:    "https://test-proxy.example.com",
+			wantSuccess: true,
+		},
+		{
+			name:        "default proxy refused",
+			errContains: "public proxy proxy.golang.org",
+		},
+		{
+			name:        "public proxy in list refused",
+			proxyURL:    "https://test-proxy.example.com,https://goproxy.io",
+			errContains: "public proxy goproxy.io",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests++
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			config := map[string]any{
+				"module_path":          "github.com/user/repo",
+				"insecure_skip_verify": true,
+			}
+			if tt.proxyURL != "" {
+				config["proxy_url"] = tt.proxyURL
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: "v1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess {
+				t.Fatalf("expected success=%v, got %v (error: %s)", tt.wantSuccess, resp.Success, resp.Error)
+			}
+
+			if tt.wantSuccess {
+				if !strings.HasPrefix(resp.Message, insecureSkipVerifyWarning) {
+					t.Errorf("expected warning prefix, got: %s", resp.Message)
+				}
+				return
+			}
+			if requests != 0 {
+				t.Errorf("expected no requests, got %d", requests)
+			}
+			if !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing %q, got: %s", tt.errContains, resp.Error)
+			}
+		})
+	}
+}
+
+func TestValidateInsecureSkipVerify(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxyURL  string
+		wantValid bool
+	}{
+		{name: "test proxy", proxyURL: "https://test-proxy.example.com", wantValid: true},
+		{name: "default proxy"},
+		{name: "explicit public proxy", proxyURL: "https://goproxy.cn"},
+	}
+
+	p := &GoModPlugin{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]any{
+				"module_path":          "github.com/user/repo",
+				"insecure_skip_verify": true,
+			}
+			if tt.proxyURL != "" {
+				config["proxy_url"] = tt.proxyURL
+			}
+
+			resp, err := p.Validate(context.Background(), config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Fatalf("expected valid=%v, got %v (errors: %v)", tt.wantValid, resp.Valid, resp.Errors)
+			}
+			if !tt.wantValid && resp.Errors[0].Field != "insecure_skip_verify" {
+				t.Errorf("expected error on field insecure_skip_verify, got %s", resp.Errors[0].Field)
+			}
+		})
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		noEnv    string
+		config   map[string]any
+		expected string
+	}{
+		{
+			name:     "from HTTPS_PROXY",
+			env:      "http://egress.internal:3128",
+			config:   map[string]any{},
+			expected: "http://egress.internal:3128",
+		},
+		{
+			name:     "NO_PROXY excludes the proxy host",
+			env:      "http://egress.internal:3128",
+			noEnv:    "proxy.golang.org",
+			config:   map[string]any{},
+			expected: "",
+		},
+		{
+			name:     "https_proxy overrides the environment",
+			env:      "http://egress.internal:3128",
+			config:   map[string]any{"https_proxy": "http://override.internal:8080"},
+			expected: "http://override.internal:8080",
+		},
+		{
+			name:     "no_proxy overrides the environment",
+			env:      "http://egress.internal:3128",
+			config:   map[string]any{"no_proxy": ".golang.org"},
+			expected: "",
+		},
+		{
+			name:     "no proxy configured",
+			config:   map[string]any{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy", "REQUEST_METHOD"} {
+				t.Setenv(key, "")
+			}
+			t.Setenv("HTTPS_PROXY", tt.env)
+			t.Setenv("NO_PROXY", tt.noEnv)
+
+			transport := transportFor(t, tt.config)
+			if transport.Proxy == nil {
+				t.Fatal("expected transport to have a proxy function")
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "https://proxy.golang.org/example.com/mod/@v/list", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			proxyURL, err := transport.Proxy(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := ""
+			if proxyURL != nil {
+				got = proxyURL.String()
+			}
+			if got != tt.expected {
+				t.Errorf("expected proxy %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDisableHTTP2(t *testing.T) {
+	transport := transportFor(t, map[string]any{})
+	if transport.TLSNextProto != nil || transport.ForceAttemptHTTP2 {
+		t.Error("expected the default transport to be left unchanged")
+	}
+
+	transport = transportFor(t, map[string]any{"disable_http2": true})
+	if transport.TLSNextProto == nil {
+		t.Fatal("expected TLSNextProto to be set")
+	}
+	if len(transport.TLSNextProto) != 0 {
+		t.Errorf("expected empty TLSNextProto, got %d entries", len(transport.TLSNextProto))
+	}
+	if transport.ForceAttemptHTTP2 {
+		t.Error("expected ForceAttemptHTTP2 to be false")
+	}
+}
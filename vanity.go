@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// knownVCSHosts are hosts whose module paths map directly onto repositories,
+// so they serve no vanity go-import meta tag worth checking.
+var knownVCSHosts = []string{"github.com", "gitlab.com", "bitbucket.org", "launchpad.net"}
+
+// goImportVCS lists the version control systems a go-import meta tag may name.
+var goImportVCS = map[string]bool{
+	"bzr": true, "fossil": true, "git": true, "hg": true, "mod": true, "svn": true,
+}
+
+// goImport is a parsed <meta name="go-import" content="prefix vcs repo"> tag.
+type goImport struct {
+	Prefix string
+	VCS    string
+	Repo   string
+}
+
+// addOutputs records the resolved repository in the response outputs. It is a
+// no-op on a nil receiver, when no vanity check was made.
+func (imp *goImport) addOutputs(outputs map[string]any) {
+	if imp == nil {
+		return
+	}
+	outputs["vanity_repo"] = imp.Repo
+	outputs["vanity_vcs"] = imp.VCS
+}
+
+// isVanityPath reports whether the module is hosted somewhere other than a
+// known VCS host.
+func isVanityPath(modulePath string) bool {
+	host, _, _ := strings.Cut(modulePath, "/")
+	for _, known := range knownVCSHosts {
+		if host == known {
+			return false
+		}
+	}
+	return true
+}
+
+// checkVanity fetches https://{module}?go-get=1 and returns the go-import
+// meta tag that applies to the module, checking that it names a usable
+// repository. Proxy credentials are never sent to the vanity host.
+func (p *GoModPlugin) checkVanity(ctx context.Context, cfg *Config) (*goImport, error) {
+	requestURL := "https://" + cfg.ModulePath + "?go-get=1"
+	if err := cfg.checkRequestURL(ctx, requestURL); err != nil {
+		return nil, err
+	}
+
+	anonymous := cfg.anonymous()
+	client, err := anonymous.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, body, err := proxyGet(ctx, client, anonymous, requestURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vanity host returned status %d", resp.StatusCode)
+	}
+
+	imports, err := parseGoImports(body)
+	if err != nil {
+		return nil, err
+	}
+	for _, imp := range imports {
+		if cfg.ModulePath != imp.Prefix && !strings.HasPrefix(cfg.ModulePath, imp.Prefix+"/") {
+			continue
+		}
+		if !goImportVCS[imp.VCS] {
+			return nil, fmt.Errorf("go-import meta tag names unknown VCS %q", imp.VCS)
+		}
+		if repo, err := url.Parse(imp.Repo); err != nil || repo.Scheme == "" || repo.Host == "" {
+			return nil, fmt.Errorf("go-import meta tag has invalid repository root %q", imp.Repo)
+		}
+		return &imp, nil
+	}
+	return nil, fmt.Errorf("no go-import meta tag found for %s", cfg.ModulePath)
+}
+
+// parseGoImports extracts the go-import meta tags from an HTML page. Like the
+// go command, it reads the page leniently and stops at the <body>.
+func parseGoImports(page []byte) ([]goImport, error) {
+	d := xml.NewDecoder(bytes.NewReader(page))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	var imports []goImport
+	for {
+		t, err := d.RawToken()
+		if err != nil {
+			if errors.Is(err, io.EOF) || len(imports) > 0 {
+				return imports, nil
+			}
+			return nil, fmt.Errorf("failed to parse vanity page: %w", err)
+		}
+		e, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(e.Name.Local, "body") {
+			return imports, nil
+		}
+		if !strings.EqualFold(e.Name.Local, "meta") || metaAttr(e, "name") != "go-import" {
+			continue
+		}
+		if f := strings.Fields(metaAttr(e, "content")); len(f) == 3 {
+			imports = append(imports, goImport{Prefix: f[0], VCS: f[1], Repo: f[2]})
+		}
+	}
+}
+
+// metaAttr returns the value of the named attribute, or "".
+func metaAttr(e xml.StartElement, name string) string {
+	for _, attr := range e.Attr {
+		if strings.EqualFold(attr.Name.Local, name) {
+			return attr.Value
+		}
+	}
+	return ""
+}
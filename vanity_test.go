@@ -0,0 +1,166 @@
+// Package main provides tests for vanity import path checks.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+const vanityPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta name="go-import" content="go.example.com/zap git https://github.com/example/zap">
+<meta name="go-source" content="go.example.com/zap https://github.com/example/zap https://github.com/example/zap/tree/master{/dir} https://github.com/example/zap/tree/master{/dir}/{file}#L{line}">
+</head>
+<body>Nothing to see here.</body>
+</html>`
+
+func TestParseGoImports(t *testing.T) {
+	imports, err := parseGoImports([]byte(vanityPage))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []goImport{{Prefix: "go.example.com/zap", VCS: "git", Repo: "https://github.com/example/zap"}}
+	if fmt.Sprint(imports) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, imports)
+	}
+}
+
+func TestIsVanityPath(t *testing.T) {
+	tests := []struct {
+		modulePath string
+		expected   bool
+	}{
+		{modulePath: "github.com/user/repo", expected: false},
+		{modulePath: "gitlab.com/group/repo", expected: false},
+		{modulePath: "go.uber.org/zap", expected: true},
+		{modulePath: "golang.org/x/net", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modulePath, func(t *testing.T) {
+			if got := isVanityPath(tt.modulePath); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExecuteCheckVanity(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		modulePath      string
+		vanityStatus    int
+		vanityBody      string
+		expectedSuccess bool
+		expectedRepo    any
+		expectedPaths   []string
+		errContains     string
+	}{
+		{
+			name:            "well-formed meta tag",
+			modulePath:      "go.example.com/zap",
+			vanityStatus:    http.StatusOK,
+			vanityBody:      vanityPage,
+			expectedSuccess: true,
+			expectedRepo:    "https://github.com/example/zap",
+			expectedPaths:   []string{"go.example.com/zap?go-get=1", "proxy.golang.org/go.example.com/zap/@v/v1.0.0.info"},
+		},
+		{
+			name:            "prefix covers a subdirectory module",
+			modulePath:      "go.example.com/zap/exp",
+			vanityStatus:    http.StatusOK,
+			vanityBody:      vanityPage,
+			expectedSuccess: true,
+			expectedRepo:    "https://github.com/example/zap",
+			expectedPaths:   []string{"go.example.com/zap/exp?go-get=1", "proxy.golang.org/go.example.com/zap/exp/@v/v1.0.0.info"},
+		},
+		{
+			name:          "missing meta tag",
+			modulePath:    "go.example.com/zap",
+			vanityStatus:  http.StatusOK,
+			vanityBody:    `<html><head><title>zap</title></head><body></body></html>`,
+			expectedPaths: []string{"go.example.com/zap?go-get=1"},
+			errContains:   "no go-import meta tag found for go.example.com/zap",
+		},
+		{
+			name:          "invalid repository root",
+			modulePath:    "go.example.com/zap",
+			vanityStatus:  http.StatusOK,
+			vanityBody:    `<meta name="go-import" content="go.example.com/zap git github.com/example/zap">`,
+			expectedPaths: []string{"go.example.com/zap?go-get=1"},
+			errContains:   "invalid repository root",
+		},
+		{
+			name:          "vanity host error",
+			modulePath:    "go.example.com/zap",
+			vanityStatus:  http.StatusNotFound,
+			expectedPaths: []string{"go.example.com/zap?go-get=1"},
+			errContains:   "vanity host returned status 404",
+		},
+		{
+			name:            "known VCS host is not checked",
+			modulePath:      "github.com/example/zap",
+			expectedSuccess: true,
+			expectedPaths:   []string{"proxy.golang.org/github.com/example/zap/@v/v1.0.0.info"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					path := req.URL.Host + req.URL.Path
+					if req.URL.RawQuery != "" {
+						path += "?" + req.URL.RawQuery
+						if req.Header.Get("Authorization") != "" {
+							t.Error("expected no credentials on the vanity request")
+						}
+						paths = append(paths, path)
+						return mockResponse(tt.vanityStatus, tt.vanityBody), nil
+					}
+					paths = append(paths, path)
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":  tt.modulePath,
+					"check_vanity": true,
+					"proxy_token":  "s3cr3t",
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+			if resp.Success && resp.Outputs["vanity_repo"] != tt.expectedRepo {
+				t.Errorf("expected vanity_repo %v, got %v", tt.expectedRepo, resp.Outputs["vanity_repo"])
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(tt.expectedPaths) {
+				t.Errorf("expected requests %v, got %v", tt.expectedPaths, paths)
+			}
+		})
+	}
+}
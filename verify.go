@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+)
+
+// fetchVersionInfo retrieves and decodes a version info document.
+func fetchVersionInfo(ctx context.Context, client HTTPClient, cfg *Config, requestURL string) (*proxy.VersionInfo, error) {
+	resp, body, err := proxyGet(ctx, client, cfg, requestURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var info proxy.VersionInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("invalid version info response: %w", err)
+	}
+	return &info, nil
+}
+
+// minVerifyInterval is the shortest delay between verification polls, so a
+// zero or tiny retry_backoff_ms cannot flood the proxy.
+// Can be overridden in tests.
+var minVerifyInterval = time.Second
+
+// verifyIndexed polls the proxy's .info endpoint until it reports the given
+// version, using retry_backoff_ms as the poll interval but never polling more
+// often than minVerifyInterval. It gives up once verify_timeout has elapsed.
+func (p *GoModPlugin) verifyIndexed(ctx context.Context, cfg *Config, proxyURL, version string) (*proxy.VersionInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(cfg.VerifyTimeout)*time.Second)
+	defer cancel()
+
+	infoURL := proxy.ModuleURL(proxyURL, cfg.ModulePath, proxy.VersionEndpoint(version, ".info"))
+	client, err := cfg.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	interval := max(time.Duration(cfg.RetryBackoffMs)*time.Millisecond, minVerifyInterval)
+
+	for {
+		info, err := fetchVersionInfo(ctx, client, cfg, infoURL)
+		if err == nil {
+			if info.Version == version {
+				return info, nil
+			}
+			err = fmt.Errorf("proxy reports version %q", info.Version)
+		}
+
+		if sleepErr := proxy.SleepContext(ctx, interval); sleepErr != nil {
+			return nil, fmt.Errorf("version %s not confirmed within %ds: %w", version, cfg.VerifyTimeout, err)
+		}
+	}
+}
+
+// fetchLatest queries the proxy's @latest endpoint for the module.
+func (p *GoModPlugin) fetchLatest(ctx context.Context, cfg *Config, proxyURL string) (*proxy.VersionInfo, error) {
+	client, err := cfg.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return fetchVersionInfo(ctx, client, cfg, proxy.ModuleURL(proxyURL, cfg.ModulePath, "@latest"))
+}
+
+// detectLatestVersion returns the version reported by the first proxy whose
+// @latest endpoint answers with a non-empty version.
+func (p *GoModPlugin) detectLatestVersion(ctx context.Context, cfg *Config) (string, error) {
+	proxies := parseProxyList(cfg.ProxyURL, cfg.proxyPolicy())
+	if len(proxies.URLs) == 0 {
+		return "", fmt.Errorf("no usable proxy URL configured")
+	}
+
+	var lastErr error
+	for _, proxyURL := range proxies.URLs {
+		if lastErr = cfg.checkRequestURL(ctx, proxy.ModuleURL(proxyURL, cfg.ModulePath, "@latest")); lastErr != nil {
+			continue
+		}
+		info, err := p.fetchLatest(ctx, cfg, proxyURL)
+		switch {
+		case err != nil:
+			lastErr = err
+		case info.Version == "":
+			lastErr = fmt.Errorf("proxy reported no latest version")
+		default:
+			return info.Version, nil
+		}
+	}
+	return "", fmt.Errorf("failed to detect latest version: %w", lastErr)
+}
+
+// fetchGoMod retrieves the raw go.mod served by the proxy for the version.
+func (p *GoModPlugin) fetchGoMod(ctx context.Context, cfg *Config, proxyURL, version string) ([]byte, error) {
+	client, err := cfg.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	resp, body, err := proxyGet(ctx, client, cfg, proxy.ModuleURL(proxyURL, cfg.ModulePath, proxy.VersionEndpoint(version, ".mod")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch go.mod: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch go.mod: proxy returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+// checkModFile fetches the go.mod served by the proxy for the version and
+// confirms its module directive matches the configured module path.
+func (p *GoModPlugin) checkModFile(ctx context.Context, cfg *Config, proxyURL, version string) error {
+	body, err := p.fetchGoMod(ctx, cfg, proxyURL, version)
+	if err != nil {
+		return err
+	}
+
+	modulePath, err := parseModulePath(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid go.mod served by proxy: %w", err)
+	}
+	if modulePath != cfg.ModulePath {
+		// A missing or stale /vN suffix is the most common cause; say so.
+		if majorSuffixPattern.ReplaceAllString(modulePath, "") == majorSuffixPattern.ReplaceAllString(cfg.ModulePath, "") {
+			return fmt.Errorf("go.mod declares module %s, expected %s (major version suffix mismatch)", modulePath, cfg.ModulePath)
+		}
+		return fmt.Errorf("go.mod declares module %s, expected %s", modulePath, cfg.ModulePath)
+	}
+	return nil
+}
+
+// checkZip sends a HEAD request for the version's zip and confirms the proxy
+// serves a non-empty archive, catching truncated or missing uploads.
+func (p *GoModPlugin) checkZip(ctx context.Context, cfg *Config, proxyURL, version string) error {
+	client, err := cfg.newHTTPClient()
+	if err != nil {
+		return err
+	}
+	resp, _, err := proxyDo(ctx, client, cfg, http.MethodHead, proxy.ModuleURL(proxyURL, cfg.ModulePath, proxy.VersionEndpoint(version, ".zip")))
+	if err != nil {
+		return fmt.Errorf("failed to check module zip: %w", err)
+	}
+	switch {
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("module zip check failed: proxy returned status %d for the %s zip", resp.StatusCode, version)
+	case resp.ContentLength < 0:
+		return fmt.Errorf("module zip check failed: proxy did not report a Content-Length for the %s zip", version)
+	case resp.ContentLength == 0:
+		return fmt.Errorf("module zip check failed: proxy serves an empty zip for %s", version)
+	}
+	return nil
+}
@@ -0,0 +1,802 @@
+// Package main provides tests for proxy index verification.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestExecuteVerify(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	// Poll at retry_backoff_ms so a second poll fits within verify_timeout.
+	originalInterval := minVerifyInterval
+	defer func() { minVerifyInterval = originalInterval }()
+	minVerifyInterval = 0
+
+	tests := []struct {
+		name            string
+		responses       []*http.Response
+		expectedSuccess bool
+		expectedTime    string
+		errContains     string
+	}{
+		{
+			name: "version confirmed after polling",
+			responses: []*http.Response{
+				mockResponse(http.StatusOK, `{}`),
+				mockResponse(http.StatusNotFound, "not found"),
+				mockResponse(http.StatusOK, `{"Version":"v1.2.3","Time":"2024-01-01T00:00:00Z"}`),
+			},
+			expectedSuccess: true,
+			expectedTime:    "2024-01-01T00:00:00Z",
+		},
+		{
+			name: "version never confirmed",
+			responses: []*http.Response{
+				mockResponse(http.StatusOK, `{}`),
+			},
+			expectedSuccess: false,
+			errContains:     "not confirmed within 1s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if strings.HasSuffix(req.URL.Path, "/@latest") {
+						return mockResponse(http.StatusOK, `{"Version":"v1.2.3","Time":"2024-01-01T00:00:00Z"}`), nil
+					}
+					calls++
+					if calls <= len(tt.responses) {
+						return tt.responses[calls-1], nil
+					}
+					// Keep serving a stale version.
+					return mockResponse(http.StatusOK, `{"Version":"v1.2.2","Time":"2023-12-01T00:00:00Z"}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			ctx := context.Background()
+
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":      "github.com/example/module",
+					"verify":           true,
+					"verify_timeout":   1,
+					"retry_backoff_ms": 50,
+				},
+				Context: plugin.ReleaseContext{Version: "v1.2.3"},
+				DryRun:  false,
+			}
+
+			resp, err := p.Execute(ctx, req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+
+			if tt.expectedSuccess {
+				if resp.Outputs["indexed_at"] != tt.expectedTime {
+					t.Errorf("indexed_at: expected '%s', got '%v'", tt.expectedTime, resp.Outputs["indexed_at"])
+				}
+				if resp.Outputs["verified"] != true {
+					t.Errorf("expected verified=true, got %v", resp.Outputs["verified"])
+				}
+			} else if !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+		})
+	}
+}
+
+func TestExecuteVerifyZeroBackoffDoesNotSpin(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	var calls atomic.Int64
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls.Add(1)
+			// Always serve a stale version so verification keeps polling.
+			return mockResponse(http.StatusOK, `{"Version":"v1.2.2"}`), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"module_path":      "github.com/example/module",
+			"verify":           true,
+			"verify_timeout":   1,
+			"retry_backoff_ms": 0,
+		},
+		Context: plugin.ReleaseContext{Version: "v1.2.3"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected verification to fail for a stale version")
+	}
+
+	// One notification plus polls at most minVerifyInterval apart.
+	if got := calls.Load(); got > 3 {
+		t.Errorf("expected at most 3 requests within the 1s verify_timeout, got %d", got)
+	}
+}
+
+func TestExecuteWithoutVerifySkipsPolling(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	calls := 0
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return mockResponse(http.StatusOK, `{"Version":"v1.2.3","Time":"2024-01-01T00:00:00Z"}`), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	req := plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  map[string]any{"module_path": "github.com/example/module"},
+		Context: plugin.ReleaseContext{Version: "v1.2.3"},
+	}
+
+	resp, err := p.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected a single request without verify, got %d", calls)
+	}
+
+	// indexed_at comes from the notification response itself, not from polling.
+	if resp.Outputs["indexed_at"] != "2024-01-01T00:00:00Z" {
+		t.Errorf("expected indexed_at from the notification response, got %v", resp.Outputs["indexed_at"])
+	}
+
+	if _, ok := resp.Outputs["verified"]; ok {
+		t.Error("expected no verified output without verify")
+	}
+}
+
+func TestExecuteVerifyLatest(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name             string
+		latestStatus     int
+		latestBody       string
+		expectedVerified bool
+		warningContains  string
+	}{
+		{
+			name:             "latest matches",
+			latestStatus:     http.StatusOK,
+			latestBody:       `{"Version":"v1.2.3","Time":"2024-01-01T00:00:00Z"}`,
+			expectedVerified: true,
+		},
+		{
+			name:             "latest differs",
+			latestStatus:     http.StatusOK,
+			latestBody:       `{"Version":"v1.3.0","Time":"2024-02-01T00:00:00Z"}`,
+			expectedVerified: false,
+			warningContains:  "@latest reports v1.3.0 instead of v1.2.3",
+		},
+		{
+			name:             "latest unavailable",
+			latestStatus:     http.StatusInternalServerError,
+			latestBody:       "boom",
+			expectedVerified: false,
+			warningContains:  "could not query @latest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if strings.HasSuffix(req.URL.Path, "/@latest") {
+						return mockResponse(tt.latestStatus, tt.latestBody), nil
+					}
+					return mockResponse(http.StatusOK, `{"Version":"v1.2.3","Time":"2024-01-01T00:00:00Z"}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path": "github.com/example/module",
+					"verify":      true,
+				},
+				Context: plugin.ReleaseContext{Version: "v1.2.3"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// A stale @latest never fails the release.
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+
+			if resp.Outputs["verified"] != tt.expectedVerified {
+				t.Errorf("verified: expected %v, got %v", tt.expectedVerified, resp.Outputs["verified"])
+			}
+
+			if tt.warningContains == "" {
+				if strings.Contains(resp.Message, "warning") {
+					t.Errorf("expected no warning, got: %s", resp.Message)
+				}
+			} else if !strings.Contains(resp.Message, tt.warningContains) {
+				t.Errorf("expected warning containing '%s', got: %s", tt.warningContains, resp.Message)
+			}
+		})
+	}
+}
+
+func TestExecuteCheckMod(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		modBody         string
+		modStatus       int
+		expectedSuccess bool
+		errContains     string
+	}{
+		{
+			name:            "matching module directive",
+			modBody:         "module github.com/user/repo\n\ngo 1.22\n",
+			modStatus:       http.StatusOK,
+			expectedSuccess: true,
+		},
+		{
+			name:        "mismatching module directive",
+			modBody:     "module github.com/user/other\n\ngo 1.22\n",
+			modStatus:   http.StatusOK,
+			errContains: "go.mod declares module github.com/user/other, expected github.com/user/repo",
+		},
+		{
+			name:        "case differs",
+			modBody:     "module github.com/User/repo\n",
+			modStatus:   http.StatusOK,
+			errContains: "go.mod declares module github.com/User/repo",
+		},
+		{
+			name:        "go.mod not served",
+			modStatus:   http.StatusNotFound,
+			errContains: "failed to fetch go.mod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					if strings.HasSuffix(req.URL.Path, ".mod") {
+						return mockResponse(tt.modStatus, tt.modBody), nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path": "github.com/user/repo",
+					"check_mod":   true,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+			if len(paths) != 2 || paths[1] != "/github.com/user/repo/@v/v1.0.0.mod" {
+				t.Errorf("expected .info then .mod requests, got %v", paths)
+			}
+		})
+	}
+}
+
+func TestExecuteCheckZip(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		zipStatus       int
+		contentLength   int64
+		expectedSuccess bool
+		errContains     string
+	}{
+		{
+			name:            "zip served",
+			zipStatus:       http.StatusOK,
+			contentLength:   48213,
+			expectedSuccess: true,
+		},
+		{
+			name:          "empty zip",
+			zipStatus:     http.StatusOK,
+			contentLength: 0,
+			errContains:   "proxy serves an empty zip for v1.0.0",
+		},
+		{
+			name:          "unknown length",
+			zipStatus:     http.StatusOK,
+			contentLength: -1,
+			errContains:   "did not report a Content-Length",
+		},
+		{
+			name:        "zip not found",
+			zipStatus:   http.StatusNotFound,
+			errContains: "proxy returned status 404 for the v1.0.0 zip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var zipRequests []*http.Request
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if strings.HasSuffix(req.URL.Path, ".zip") {
+						zipRequests = append(zipRequests, req)
+						resp := mockResponse(tt.zipStatus, "")
+						resp.ContentLength = tt.contentLength
+						return resp, nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path": "github.com/user/repo",
+					"check_zip":   true,
+				},
+				Context: plugin.ReleaseContext{Version: "1.0.0"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+			if len(zipRequests) != 1 {
+				t.Fatalf("expected 1 zip request, got %d", len(zipRequests))
+			}
+			if zipRequests[0].Method != http.MethodHead {
+				t.Errorf("expected HEAD request, got %s", zipRequests[0].Method)
+			}
+			if zipRequests[0].URL.Path != "/github.com/user/repo/@v/v1.0.0.zip" {
+				t.Errorf("unexpected zip URL %s", zipRequests[0].URL)
+			}
+		})
+	}
+}
+
+func TestExecuteReportsIndexedInfo(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		body            string
+		expectedTime    any
+		expectedVersion any
+	}{
+		{
+			name:            "version info body",
+			body:            `{"Version":"v1.2.3","Time":"2024-01-01T00:00:00Z"}`,
+			expectedTime:    "2024-01-01T00:00:00Z",
+			expectedVersion: "v1.2.3",
+		},
+		{
+			name: "non-JSON body",
+			body: "ok",
+		},
+		{
+			name: "empty object",
+			body: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return mockResponse(http.StatusOK, tt.body), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  map[string]any{"module_path": "github.com/user/repo"},
+				Context: plugin.ReleaseContext{Version: "1.2.3"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+
+			if resp.Outputs["indexed_at"] != tt.expectedTime {
+				t.Errorf("expected indexed_at %v, got %v", tt.expectedTime, resp.Outputs["indexed_at"])
+			}
+			if resp.Outputs["indexed_time"] != tt.expectedTime {
+				t.Errorf("expected indexed_time %v, got %v", tt.expectedTime, resp.Outputs["indexed_time"])
+			}
+			if resp.Outputs["indexed_version"] != tt.expectedVersion {
+				t.Errorf("expected indexed_version %v, got %v", tt.expectedVersion, resp.Outputs["indexed_version"])
+			}
+		})
+	}
+}
+
+func TestExecuteWarnsOnNonJSONVersionInfo(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mockResponse(http.StatusOK, "ok"), nil
+		},
+	}
+	buf := captureLogs(t)
+
+	p := &GoModPlugin{}
+	resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+		Hook: plugin.HookPostPublish,
+		Config: map[string]any{
+			"module_path": "github.com/user/repo",
+			"log_level":   "warn",
+		},
+		Context: plugin.ReleaseContext{Version: "1.2.3"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("expected success, got error: %s", resp.Error)
+	}
+
+	var warned bool
+	for _, entry := range decodeLogs(t, buf) {
+		if entry["level"] == logLevelWarn && strings.Contains(entry["msg"].(string), "not JSON") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("expected a warning about the non-JSON body, got logs: %s", buf.String())
+	}
+}
+
+func TestExecuteNotifyLatest(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name           string
+		notifyLatest   bool
+		latestStatus   int
+		expectedLatest any
+		expectWarning  bool
+		expectedCalls  int
+	}{
+		{
+			name:           "latest updated",
+			notifyLatest:   true,
+			latestStatus:   http.StatusOK,
+			expectedLatest: "v1.2.3",
+			expectedCalls:  2,
+		},
+		{
+			name:          "latest fails with warning only",
+			notifyLatest:  true,
+			latestStatus:  http.StatusInternalServerError,
+			expectWarning: true,
+			expectedCalls: 2,
+		},
+		{
+			name:          "disabled",
+			latestStatus:  http.StatusOK,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedLatest *http.Request
+			calls := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					calls++
+					if strings.HasSuffix(req.URL.Path, "/@latest") {
+						capturedLatest = req
+						return mockResponse(tt.latestStatus, `{"Version":"v1.2.3"}`), nil
+					}
+					return mockResponse(http.StatusOK, `{"Version":"v1.2.3"}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":   "github.com/example/module",
+					"proxy_url":     "https://goproxy.example.com",
+					"notify_latest": tt.notifyLatest,
+					"proxy_token":   "s3cr3t",
+				},
+				Context: plugin.ReleaseContext{Version: "1.2.3"},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("expected %d requests, got %d", tt.expectedCalls, calls)
+			}
+			if resp.Outputs["latest_version"] != tt.expectedLatest {
+				t.Errorf("expected latest_version %v, got %v", tt.expectedLatest, resp.Outputs["latest_version"])
+			}
+			if strings.Contains(resp.Message, "warning") != tt.expectWarning {
+				t.Errorf("unexpected warning state in message: %s", resp.Message)
+			}
+			if capturedLatest != nil && capturedLatest.Header.Get("Authorization") != "Bearer s3cr3t" {
+				t.Error("expected @latest request to reuse proxy authentication")
+			}
+		})
+	}
+}
+
+func TestExecuteAutoVersion(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		autoVersion     bool
+		releaseCtx      plugin.ReleaseContext
+		latestStatus    int
+		latestBody      string
+		expectedSuccess bool
+		expectedPaths   []string
+		errContains     string
+	}{
+		{
+			name:            "detected from latest",
+			autoVersion:     true,
+			latestStatus:    http.StatusOK,
+			latestBody:      `{"Version":"v1.4.0","Time":"2024-01-01T00:00:00Z"}`,
+			expectedSuccess: true,
+			expectedPaths:   []string{"/github.com/example/module/@latest", "/github.com/example/module/@v/v1.4.0.info"},
+		},
+		{
+			name:          "empty latest falls back to required error",
+			autoVersion:   true,
+			latestStatus:  http.StatusOK,
+			latestBody:    `{"Version":""}`,
+			expectedPaths: []string{"/github.com/example/module/@latest"},
+			errContains:   "version is required",
+		},
+		{
+			name:          "failed latest falls back to required error",
+			autoVersion:   true,
+			latestStatus:  http.StatusNotFound,
+			expectedPaths: []string{"/github.com/example/module/@latest"},
+			errContains:   "version is required",
+		},
+		{
+			name:            "release version wins",
+			autoVersion:     true,
+			releaseCtx:      plugin.ReleaseContext{Version: "1.3.0"},
+			expectedSuccess: true,
+			expectedPaths:   []string{"/github.com/example/module/@v/v1.3.0.info"},
+		},
+		{
+			name:          "disabled",
+			latestStatus:  http.StatusOK,
+			latestBody:    `{"Version":"v1.4.0"}`,
+			expectedPaths: nil,
+			errContains:   "version is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					if strings.HasSuffix(req.URL.Path, "/@latest") {
+						return mockResponse(tt.latestStatus, tt.latestBody), nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":  "github.com/example/module",
+					"auto_version": tt.autoVersion,
+				},
+				Context: tt.releaseCtx,
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(tt.expectedPaths) {
+				t.Errorf("expected requests %v, got %v", tt.expectedPaths, paths)
+			}
+		})
+	}
+}
+
+func TestExecuteVerifyGoMod(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name            string
+		modulePath      string
+		version         string
+		modBody         string
+		expectedSuccess bool
+		errContains     string
+	}{
+		{
+			name:            "matching v2 module",
+			modulePath:      "github.com/user/repo/v2",
+			version:         "2.1.0",
+			modBody:         "module github.com/user/repo/v2\n",
+			expectedSuccess: true,
+		},
+		{
+			name:        "go.mod missing major suffix",
+			modulePath:  "github.com/user/repo/v2",
+			version:     "2.1.0",
+			modBody:     "module github.com/user/repo\n",
+			errContains: "major version suffix mismatch",
+		},
+		{
+			name:        "malformed go.mod",
+			modulePath:  "github.com/user/repo",
+			version:     "1.0.0",
+			modBody:     "go 1.22\n",
+			errContains: "invalid go.mod served by proxy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if strings.HasSuffix(req.URL.Path, ".mod") {
+						return mockResponse(http.StatusOK, tt.modBody), nil
+					}
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":  tt.modulePath,
+					"verify_gomod": true,
+				},
+				Context: plugin.ReleaseContext{Version: tt.version},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing '%s', got: %s", tt.errContains, resp.Error)
+			}
+		})
+	}
+}
+
+func TestFetchGoMod(t *testing.T) {
+	// Store original client and restore after test.
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	const goMod = "module github.com/user/repo\n\ngo 1.22\n"
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/github.com/user/repo/@v/v1.0.0.mod" {
+				t.Errorf("unexpected request path %s", req.URL.Path)
+			}
+			return mockResponse(http.StatusOK, goMod), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	cfg := &Config{ModulePath: "github.com/user/repo", Timeout: 30}
+	body, err := p.fetchGoMod(context.Background(), cfg, "https://proxy.golang.org", "v1.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != goMod {
+		t.Errorf("expected raw go.mod, got %q", body)
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relicta-tech/plugin-gomod/proxy"
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// majorSuffixPattern matches a trailing /vN major version path element.
+// Only v2 and above are valid suffixes; v0 and v1 modules have none.
+var majorSuffixPattern = regexp.MustCompile(`/v([2-9]|[1-9][0-9]+)$`)
+
+// gitDescribePattern matches the -N-gSHA suffix git describe appends when
+// HEAD is N commits past the nearest tag.
+var gitDescribePattern = regexp.MustCompile(`-[0-9]+-g[0-9a-f]{4,40}$`)
+
+// stripGitDescribe reduces a git describe string such as
+// "v1.2.3-4-gdeadbee" to the tag it describes, "v1.2.3".
+func stripGitDescribe(version string) string {
+	return gitDescribePattern.ReplaceAllString(version, "")
+}
+
+// pseudoVersionPattern matches the three pseudo-version forms the go command
+// generates for untagged commits: vX.0.0-yyyymmddhhmmss-abcdefabcdef,
+// vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef and vX.Y.Z-0.yyyymmddhhmmss-abcdefabcdef.
+var pseudoVersionPattern = regexp.MustCompile(`^v[0-9]+\.(?:0\.0-|[0-9]+\.[0-9]+-(?:[^+]*\.)?0\.)([0-9]{14})-[0-9a-f]{12}(?:\+incompatible)?$`)
+
+// pseudoVersionLikePattern matches versions ending in a timestamp and
+// revision, which are only valid as well-formed pseudo-versions.
+var pseudoVersionLikePattern = regexp.MustCompile(`[-.][0-9]{8,}-[0-9a-zA-Z]+(?:\+incompatible)?$`)
+
+// isPseudoVersion reports whether version is a well-formed pseudo-version
+// with a valid commit timestamp.
+func isPseudoVersion(version string) bool {
+	m := pseudoVersionPattern.FindStringSubmatch(version)
+	if m == nil {
+		return false
+	}
+	_, err := time.Parse("20060102150405", m[1])
+	return err == nil
+}
+
+// readVersionFile reads the release version from versionFile, retrying
+// relative paths against the release's workspace directory like go_mod_path.
+// Surrounding whitespace and any git describe suffix are trimmed, since the
+// file is often written by "git describe", and an empty file is an error.
+func readVersionFile(versionFile string, env map[string]string) (string, error) {
+	data, err := os.ReadFile(versionFile)
+	if err != nil && !filepath.IsAbs(versionFile) {
+		for _, key := range workspaceEnvVars {
+			dir := env[key]
+			if dir == "" {
+				continue
+			}
+			if wsData, wsErr := os.ReadFile(filepath.Join(dir, versionFile)); wsErr == nil {
+				data, err = wsData, nil
+				break
+			}
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	version := strings.TrimSpace(string(data))
+	if version == "" {
+		return "", fmt.Errorf("version file %s is empty", versionFile)
+	}
+	return stripGitDescribe(version), nil
+}
+
+// validateVersion checks that a v-prefixed version is valid semver so that
+// malformed tags are rejected before contacting the proxy. Versions that
+// look like pseudo-versions must also be well-formed ones.
+func validateVersion(version string) error {
+	// semver.IsValid also accepts the vMAJOR and vMAJOR.MINOR shorthands,
+	// which the proxy never serves, so the version must also be canonical.
+	if !semver.IsValid(version) || semver.Canonical(version)+semver.Build(version) != version {
+		return fmt.Errorf("version is not valid semver: %q (expected vMAJOR.MINOR.PATCH[-PRERELEASE][+BUILD])", version)
+	}
+	if pseudoVersionLikePattern.MatchString(version) && !isPseudoVersion(version) {
+		return fmt.Errorf("malformed pseudo-version: %q (expected vX.Y.Z-yyyymmddhhmmss-abcdefabcdef)", version)
+	}
+	return nil
+}
+
+// majorVersion returns the major version number of a v-prefixed version.
+func majorVersion(version string) (int, error) {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("cannot determine major version of %q", version)
+	}
+	return n, nil
+}
+
+// gopkgInPattern matches a gopkg.in module path, whose major version is the
+// .vN suffix of its last element, e.g. gopkg.in/yaml.v3 or gopkg.in/user/pkg.v1.
+var gopkgInPattern = regexp.MustCompile(`^gopkg\.in/(?:[a-zA-Z0-9_-]+/)?[a-zA-Z0-9_-]+\.v(0|[1-9][0-9]*)(?:-unstable)?$`)
+
+// gopkgInMajor returns the major version declared by a gopkg.in module path
+// and whether modulePath is a well-formed gopkg.in path. Unlike /vN suffixes,
+// .v0 and .v1 are explicit: every gopkg.in path names its major version.
+func gopkgInMajor(modulePath string) (int, bool) {
+	m := gopkgInPattern.FindStringSubmatch(modulePath)
+	if m == nil {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n, true
+}
+
+// pathMajor returns the major version declared by a module path's /vN
+// suffix, or 0 if the path has none.
+func pathMajor(modulePath string) int {
+	m := majorSuffixPattern.FindStringSubmatch(modulePath)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+// normalizeIncompatibleVersion returns the +incompatible form of a v2+
+// version requested for a module path without a /vN suffix, since that is
+// the only form the proxy serves for such modules. Other versions, including
+// ones that already carry build metadata, are returned unchanged.
+func normalizeIncompatibleVersion(modulePath, version string) string {
+	if _, gopkgIn := gopkgInMajor(modulePath); gopkgIn {
+		return version
+	}
+	if pathMajor(modulePath) != 0 || strings.Contains(version, "+") {
+		return version
+	}
+	if major, err := majorVersion(version); err != nil || major < 2 {
+		return version
+	}
+	return version + "+incompatible"
+}
+
+// checkMajorVersionSuffix verifies semantic import versioning: a path ending
+// in /vN (N >= 2) only publishes vN.x.x versions, and a path without a suffix
+// only publishes v0 or v1. +incompatible versions predate go.mod and are only
+// valid on paths without a suffix. gopkg.in paths publish exactly the major
+// version named by their .vN suffix.
+func checkMajorVersionSuffix(modulePath, version string) error {
+	major, err := majorVersion(version)
+	if err != nil {
+		return err
+	}
+	incompatible := strings.HasSuffix(version, "+incompatible")
+
+	if suffix, gopkgIn := gopkgInMajor(modulePath); gopkgIn {
+		if incompatible {
+			return fmt.Errorf("gopkg.in module path %s cannot publish +incompatible version %s", modulePath, version)
+		}
+		if major != suffix {
+			return fmt.Errorf("module path %s can only publish v%d.x.x versions, got %s (major version %d)", modulePath, suffix, version, major)
+		}
+		return nil
+	}
+
+	if suffix := pathMajor(modulePath); suffix >= 2 {
+		if incompatible {
+			return fmt.Errorf("module path %s has a /v%d suffix and cannot publish +incompatible version %s", modulePath, suffix, version)
+		}
+		if major != suffix {
+			return fmt.Errorf("module path %s can only publish v%d.x.x versions, got %s (major version %d)", modulePath, suffix, version, major)
+		}
+		return nil
+	}
+
+	if major >= 2 && !incompatible {
+		return fmt.Errorf("module path %s has no major version suffix and can only publish v0 or v1 versions; it must end in /v%d to publish %s", modulePath, major, version)
+	}
+	return nil
+}
+
+// releaseVersion derives the module version to publish for modulePath from
+// the release context, adding the "v" prefix and the +incompatible suffix
+// where needed, and rejects versions the proxy would never serve.
+func releaseVersion(modulePath string, releaseCtx plugin.ReleaseContext) (string, error) {
+	version := releaseCtx.Version
+	if version == "" {
+		version = releaseCtx.TagName
+	}
+	if version == "" {
+		return "", fmt.Errorf("version is required for proxy notification")
+	}
+
+	version = proxy.NormalizeVersion(version)
+	if err := validateVersion(version); err != nil {
+		return "", fmt.Errorf("invalid module version: %w", err)
+	}
+
+	// v2+ versions of modules without a /vN path are served as +incompatible.
+	version = normalizeIncompatibleVersion(modulePath, version)
+
+	// v2+ modules must be published under a matching /vN path. The explicit
+	// check gives clearer messages; module.Check is the go command's own rule.
+	if err := checkMajorVersionSuffix(modulePath, version); err != nil {
+		return "", fmt.Errorf("invalid module version: %w", err)
+	}
+	if err := module.Check(modulePath, version); err != nil {
+		return "", fmt.Errorf("invalid module version: %w", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,598 @@
+// Package main provides tests for module version handling.
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/relicta-tech/relicta-plugin-sdk/plugin"
+)
+
+func TestCheckMajorVersionSuffix(t *testing.T) {
+	tests := []struct {
+		name        string
+		modulePath  string
+		version     string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:       "v1 without suffix",
+			modulePath: "github.com/user/repo",
+			version:    "v1.4.0",
+		},
+		{
+			name:       "v0 without suffix",
+			modulePath: "github.com/user/repo",
+			version:    "v0.3.0",
+		},
+		{
+			name:       "v2 with matching suffix",
+			modulePath: "github.com/user/repo/v2",
+			version:    "v2.0.0",
+		},
+		{
+			name:        "v3 missing suffix",
+			modulePath:  "github.com/user/repo",
+			version:     "v3.0.0",
+			wantErr:     true,
+			errContains: "must end in /v3",
+		},
+		{
+			name:        "v3 with v2 suffix",
+			modulePath:  "github.com/user/repo/v2",
+			version:     "v3.1.0",
+			wantErr:     true,
+			errContains: "can only publish v2.x.x versions, got v3.1.0",
+		},
+		{
+			name:        "v1 with v2 suffix",
+			modulePath:  "github.com/user/repo/v2",
+			version:     "v1.4.0",
+			wantErr:     true,
+			errContains: "can only publish v2.x.x versions, got v1.4.0",
+		},
+		{
+			name:        "v0 with v3 suffix",
+			modulePath:  "github.com/user/repo/v3",
+			version:     "v0.1.0",
+			wantErr:     true,
+			errContains: "can only publish v3.x.x versions",
+		},
+		{
+			name:        "incompatible on suffixed path",
+			modulePath:  "github.com/user/repo/v3",
+			version:     "v3.0.0+incompatible",
+			wantErr:     true,
+			errContains: "cannot publish +incompatible version",
+		},
+		{
+			name:       "v1 element is not a major suffix",
+			modulePath: "github.com/user/repo/v1",
+			version:    "v1.0.0",
+		},
+		{
+			name:       "v10 suffix",
+			modulePath: "github.com/user/repo/v10",
+			version:    "v10.2.0",
+		},
+		{
+			name:       "incompatible version is exempt",
+			modulePath: "github.com/user/repo",
+			version:    "v3.0.0+incompatible",
+		},
+		{
+			name:       "gopkg.in v2",
+			modulePath: "gopkg.in/pkg.v2",
+			version:    "v2.3.0",
+		},
+		{
+			name:       "gopkg.in user v1",
+			modulePath: "gopkg.in/user/pkg.v1",
+			version:    "v1.0.4",
+		},
+		{
+			name:       "gopkg.in v0",
+			modulePath: "gopkg.in/pkg.v0",
+			version:    "v0.9.0",
+		},
+		{
+			name:        "gopkg.in mismatched major",
+			modulePath:  "gopkg.in/pkg.v2",
+			version:     "v3.0.0",
+			wantErr:     true,
+			errContains: "can only publish v2.x.x versions, got v3.0.0",
+		},
+		{
+			name:        "gopkg.in v1 path with v2 version",
+			modulePath:  "gopkg.in/user/pkg.v1",
+			version:     "v2.0.0",
+			wantErr:     true,
+			errContains: "can only publish v1.x.x versions",
+		},
+		{
+			name:        "gopkg.in incompatible",
+			modulePath:  "gopkg.in/pkg.v2",
+			version:     "v2.0.0+incompatible",
+			wantErr:     true,
+			errContains: "cannot publish +incompatible version",
+		},
+		{
+			name:        "unparseable major",
+			modulePath:  "github.com/user/repo",
+			version:     "vnext",
+			wantErr:     true,
+			errContains: "cannot determine major version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMajorVersionSuffix(tt.modulePath, tt.version)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Errorf("expected error containing '%s', got: %v", tt.errContains, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNormalizeIncompatibleVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		modulePath string
+		version    string
+		expected   string
+	}{
+		{"v3 without suffix", "github.com/user/repo", "v3.0.0", "v3.0.0+incompatible"},
+		{"already incompatible", "github.com/user/repo", "v3.0.0+incompatible", "v3.0.0+incompatible"},
+		{"v3 with suffix", "github.com/user/repo/v3", "v3.0.0", "v3.0.0"},
+		{"v1 without suffix", "github.com/user/repo", "v1.2.3", "v1.2.3"},
+		{"v0 without suffix", "github.com/user/repo", "v0.1.0", "v0.1.0"},
+		{"prerelease", "github.com/user/repo", "v2.0.0-rc.1", "v2.0.0-rc.1+incompatible"},
+		{"other build metadata", "github.com/user/repo", "v2.0.0+build", "v2.0.0+build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeIncompatibleVersion(tt.modulePath, tt.version)
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExecuteUsesIncompatibleVersionInRequestURL(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	tests := []struct {
+		name        string
+		modulePath  string
+		version     string
+		expectedURL string
+	}{
+		{
+			name:        "path without suffix",
+			modulePath:  "github.com/user/repo",
+			version:     "3.0.0",
+			expectedURL: "https://proxy.golang.org/github.com/user/repo/@v/v3.0.0%2Bincompatible.info",
+		},
+		{
+			name:        "path with suffix",
+			modulePath:  "github.com/user/repo/v3",
+			version:     "3.0.0",
+			expectedURL: "https://proxy.golang.org/github.com/user/repo/v3/@v/v3.0.0.info",
+		},
+		{
+			name:        "gopkg.in path",
+			modulePath:  "gopkg.in/pkg.v2",
+			version:     "2.3.0",
+			expectedURL: "https://proxy.golang.org/gopkg.in/pkg.v2/@v/v2.3.0.info",
+		},
+		{
+			name:        "gopkg.in user path",
+			modulePath:  "gopkg.in/user/pkg.v1",
+			version:     "1.0.4",
+			expectedURL: "https://proxy.golang.org/gopkg.in/user/pkg.v1/@v/v1.0.4.info",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedURL string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requestedURL = req.URL.String()
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  map[string]any{"module_path": tt.modulePath},
+				Context: plugin.ReleaseContext{Version: tt.version},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if requestedURL != tt.expectedURL {
+				t.Errorf("expected URL %s, got %s", tt.expectedURL, requestedURL)
+			}
+		})
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		wantErr bool
+	}{
+		{name: "release", version: "v1.2.3"},
+		{name: "zero version", version: "v0.0.0"},
+		{name: "pre-release", version: "v1.0.0-rc.1"},
+		{name: "build metadata", version: "v1.0.0+meta.7"},
+		{name: "incompatible", version: "v2.0.0+incompatible"},
+		{name: "pseudo-version", version: "v0.0.0-20210101000000-abcdef123456"},
+		{name: "pseudo-version after pre-release", version: "v1.2.4-pre.0.20210101000000-abcdef123456"},
+		{name: "pseudo-version after release", version: "v1.2.4-0.20210101000000-abcdef123456"},
+		{name: "incompatible pseudo-version", version: "v2.0.0-20210101000000-abcdef123456+incompatible"},
+		{name: "pseudo-version with short timestamp", version: "v0.0.0-2021010100000-abcdef123456", wantErr: true},
+		{name: "pseudo-version with short revision", version: "v0.0.0-20210101000000-abcdef1", wantErr: true},
+		{name: "pseudo-version with invalid date", version: "v0.0.0-20211341000000-abcdef123456", wantErr: true},
+		{name: "pseudo-version with uppercase revision", version: "v0.0.0-20210101000000-ABCDEF123456", wantErr: true},
+		{name: "pseudo-version with nonzero base", version: "v1.2.0-20210101000000-abcdef123456", wantErr: true},
+		{name: "non-semver tag", version: "vrelease-1", wantErr: true},
+		{name: "missing patch", version: "v1.2", wantErr: true},
+		{name: "leading zero", version: "v01.2.3", wantErr: true},
+		{name: "empty pre-release", version: "v1.2.3-", wantErr: true},
+		{name: "leading zero in numeric pre-release", version: "v1.2.3-01", wantErr: true},
+		{name: "missing v prefix", version: "1.2.3", wantErr: true},
+		{name: "build metadata with pre-release", version: "v1.0.0-alpha.1+build.5"},
+		{name: "hyphenated pre-release identifier", version: "v1.0.0-x-y-z.1"},
+		{name: "two components", version: "v1.0", wantErr: true},
+		{name: "major only shorthand", version: "v1", wantErr: true},
+		{name: "four components", version: "v1.0.0.0", wantErr: true},
+		{name: "empty build metadata", version: "v1.0.0+", wantErr: true},
+		{name: "empty pre-release identifier", version: "v1.0.0-alpha..1", wantErr: true},
+		{name: "invalid characters", version: "v1.0.0-beta_1", wantErr: true},
+		{name: "negative component", version: "v1.-1.0", wantErr: true},
+		{name: "empty", version: "v", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVersion(tt.version)
+			if tt.wantErr && err == nil {
+				t.Errorf("expected error for %q", tt.version)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error for %q: %v", tt.version, err)
+			}
+		})
+	}
+}
+
+func TestExecuteAllowPseudo(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	const pseudo = "v0.0.0-20240101000000-abcdef123456"
+
+	tests := []struct {
+		name            string
+		config          map[string]any
+		version         string
+		expectedSuccess bool
+		errContains     string
+	}{
+		{
+			name:            "allowed by default",
+			config:          map[string]any{},
+			version:         pseudo,
+			expectedSuccess: true,
+		},
+		{
+			name:            "rejected when disabled",
+			config:          map[string]any{"allow_pseudo": false},
+			version:         pseudo,
+			expectedSuccess: false,
+			errContains:     "refusing to publish pseudo-version " + pseudo,
+		},
+		{
+			name:            "tagged release unaffected when disabled",
+			config:          map[string]any{"allow_pseudo": false},
+			version:         "v1.0.0",
+			expectedSuccess: true,
+		},
+		{
+			name:            "malformed pseudo-version",
+			config:          map[string]any{},
+			version:         "v0.0.0-2024010100000-abcdef123456",
+			expectedSuccess: false,
+			errContains:     "malformed pseudo-version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests++
+					return mockResponse(http.StatusOK, "{}"), nil
+				},
+			}
+
+			config := map[string]any{"module_path": "github.com/user/repo"}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  config,
+				Context: plugin.ReleaseContext{Version: tt.version},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success != tt.expectedSuccess {
+				t.Fatalf("Success: expected %v, got %v, error: %s", tt.expectedSuccess, resp.Success, resp.Error)
+			}
+			if tt.errContains != "" && !strings.Contains(resp.Error, tt.errContains) {
+				t.Errorf("expected error containing %q, got: %s", tt.errContains, resp.Error)
+			}
+			if !tt.expectedSuccess && requests != 0 {
+				t.Errorf("expected no requests for a rejected version, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestExecuteRejectsInvalidVersionWithoutRequest(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request to %s", req.URL)
+			return mockResponse(http.StatusOK, "{}"), nil
+		},
+	}
+
+	p := &GoModPlugin{}
+	req := plugin.ExecuteRequest{
+		Hook:    plugin.HookPostPublish,
+		Config:  map[string]any{"module_path": "github.com/user/repo"},
+		Context: plugin.ReleaseContext{Version: "release-1"},
+	}
+
+	resp, err := p.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Success {
+		t.Fatal("expected failure for a non-semver version")
+	}
+	if !strings.Contains(resp.Error, `"vrelease-1"`) {
+		t.Errorf("expected error to name the version, got: %s", resp.Error)
+	}
+}
+
+func TestExecuteRejectsMalformedVersions(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request to %s", req.URL)
+			return mockResponse(http.StatusOK, "{}"), nil
+		},
+	}
+
+	for _, version := range []string{"1.0", "1.0.0.0", "v1", "1.0.0-"} {
+		t.Run(version, func(t *testing.T) {
+			p := &GoModPlugin{}
+			req := plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  map[string]any{"module_path": "github.com/user/repo"},
+				Context: plugin.ReleaseContext{Version: version},
+			}
+
+			resp, err := p.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success {
+				t.Fatalf("expected failure for version %q", version)
+			}
+			if !strings.Contains(resp.Error, "version is not valid semver") {
+				t.Errorf("expected semver error, got: %s", resp.Error)
+			}
+		})
+	}
+}
+
+func TestStripGitDescribe(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{version: "v1.2.3", expected: "v1.2.3"},
+		{version: "v1.2.3-4-gdeadbee", expected: "v1.2.3"},
+		{version: "v1.2.3-rc.1-12-g0123456789ab", expected: "v1.2.3-rc.1"},
+		{version: "v1.2.3-beta", expected: "v1.2.3-beta"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := stripGitDescribe(tt.version); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExecuteVersionFile(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name        string
+		versionFile string
+		version     string
+		wantErr     string
+		expectedURL string
+	}{
+		{
+			name:        "clean version",
+			versionFile: writeFile("clean", "1.4.0\n"),
+			expectedURL: "https://proxy.golang.org/github.com/user/repo/@v/v1.4.0.info",
+		},
+		{
+			name:        "git describe",
+			versionFile: writeFile("describe", "  v1.4.0-7-g1a2b3c4\n"),
+			expectedURL: "https://proxy.golang.org/github.com/user/repo/@v/v1.4.0.info",
+		},
+		{
+			name:        "release context version wins",
+			versionFile: writeFile("ignored", "9.9.9"),
+			version:     "1.5.0",
+			expectedURL: "https://proxy.golang.org/github.com/user/repo/@v/v1.5.0.info",
+		},
+		{
+			name:        "context version keeps git describe suffix",
+			versionFile: writeFile("unused", "9.9.9"),
+			version:     "v1.2.0-3-gabc1234",
+			expectedURL: "https://proxy.golang.org/github.com/user/repo/@v/v1.2.0-3-gabc1234.info",
+		},
+		{
+			name:        "missing file",
+			versionFile: filepath.Join(dir, "missing"),
+			wantErr:     "cannot read version_file",
+		},
+		{
+			name:        "empty file",
+			versionFile: writeFile("empty", " \n"),
+			wantErr:     "is empty",
+		},
+		{
+			name:        "invalid version",
+			versionFile: writeFile("invalid", "1.4"),
+			wantErr:     "invalid module version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestedURL string
+			httpClient = &mockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requestedURL = req.URL.String()
+					return mockResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook: plugin.HookPostPublish,
+				Config: map[string]any{
+					"module_path":  "github.com/user/repo",
+					"version_file": tt.versionFile,
+				},
+				Context: plugin.ReleaseContext{Version: tt.version},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantErr != "" {
+				if resp.Success {
+					t.Fatal("expected failure")
+				}
+				if !strings.Contains(resp.Error, tt.wantErr) {
+					t.Errorf("expected error containing %q, got %q", tt.wantErr, resp.Error)
+				}
+				if requestedURL != "" {
+					t.Errorf("expected no request, got %s", requestedURL)
+				}
+				return
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if requestedURL != tt.expectedURL {
+				t.Errorf("expected URL %s, got %s", tt.expectedURL, requestedURL)
+			}
+		})
+	}
+}
+
+func TestExecuteModuleVersionOutput(t *testing.T) {
+	originalClient := httpClient
+	defer func() { httpClient = originalClient }()
+	httpClient = &mockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return mockResponse(http.StatusOK, `{}`), nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		modulePath string
+		version    string
+		dryRun     bool
+		expected   string
+	}{
+		{name: "live", modulePath: "github.com/user/repo", version: "1.2.3", expected: "github.com/user/repo@v1.2.3"},
+		{name: "dry run", modulePath: "github.com/user/repo", version: "v1.2.3", dryRun: true, expected: "github.com/user/repo@v1.2.3"},
+		{name: "live incompatible", modulePath: "github.com/user/repo", version: "3.0.0", expected: "github.com/user/repo@v3.0.0+incompatible"},
+		{name: "dry run incompatible", modulePath: "github.com/user/repo", version: "3.0.0", dryRun: true, expected: "github.com/user/repo@v3.0.0+incompatible"},
+		{name: "major version suffix", modulePath: "github.com/user/repo/v3", version: "3.0.0", expected: "github.com/user/repo/v3@v3.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &GoModPlugin{}
+			resp, err := p.Execute(context.Background(), plugin.ExecuteRequest{
+				Hook:    plugin.HookPostPublish,
+				Config:  map[string]any{"module_path": tt.modulePath},
+				Context: plugin.ReleaseContext{Version: tt.version},
+				DryRun:  tt.dryRun,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if resp.Outputs["module_version"] != tt.expected {
+				t.Errorf("expected module_version %s, got %v", tt.expected, resp.Outputs["module_version"])
+			}
+		})
+	}
+}